@@ -168,6 +168,7 @@ func analyzeCode(scope *types.Scope, docs *doc.Package) (imports.Importer, []tes
 
 		funcs = append(funcs, testFunc{*outputPkg, fdocs, fn})
 		importer.AddImportsFrom(sig.Params())
+		importer.AddImportsFrom(sig.Results())
 	}
 	return importer, funcs, nil
 }
@@ -273,6 +274,24 @@ func (f *testFunc) ForwardedParams() string {
 	return p
 }
 
+// Value returns the type of the value returned by assertions returning a
+// value along with their boolean result, or "" for other assertions.
+func (f *testFunc) Value() string {
+	results := f.TypeInfo.Type().(*types.Signature).Results()
+	if results.Len() != 2 {
+		return ""
+	}
+	return types.TypeString(results.At(0).Type(), f.Qualifier)
+}
+
+// Results returns the results of the assertion, as declared in assert.
+func (f *testFunc) Results() string {
+	if value := f.Value(); value != "" {
+		return "(" + value + ", bool)"
+	}
+	return "bool"
+}
+
 func (f *testFunc) ParamsFormat() string {
 	return strings.Replace(f.Params(), "msgAndArgs", "msg string, args", 1)
 }
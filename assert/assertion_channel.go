@@ -0,0 +1,188 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// chanValue returns the reflect.Value of ch if it is a channel supporting
+// dir, or fails.
+func chanValue(t TestingT, ch interface{}, dir reflect.ChanDir, msgAndArgs ...interface{}) (reflect.Value, bool) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	v := reflect.ValueOf(ch)
+	if v.Kind() != reflect.Chan {
+		return v, Fail(t, fmt.Sprintf("%#v is not a channel", ch), msgAndArgs...)
+	}
+	if v.Type().ChanDir()&dir == 0 {
+		if dir == reflect.RecvDir {
+			return v, Fail(t, fmt.Sprintf("Cannot receive from send-only channel %s", v.Type()), msgAndArgs...)
+		}
+		return v, Fail(t, fmt.Sprintf("Cannot send to receive-only channel %s", v.Type()), msgAndArgs...)
+	}
+	if v.IsNil() {
+		return v, Fail(t, "Channel is nil", msgAndArgs...)
+	}
+	return v, true
+}
+
+// receive waits up to timeout to receive from ch. It reports whether the
+// timeout elapsed, and otherwise whether a value was received or ch was
+// closed.
+func receive(ch reflect.Value, timeout time.Duration) (value reflect.Value, received, timedOut bool) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	chosen, value, received := reflect.Select([]reflect.SelectCase{
+		{Dir: reflect.SelectRecv, Chan: ch},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(timer.C)},
+	})
+	return value, received, chosen == 1
+}
+
+// Receives asserts that a value is received from the specified channel
+// within timeout, and returns it.
+//
+//	assert.Receives(t, results, time.Second)
+func Receives(t TestingT, ch interface{}, timeout time.Duration, msgAndArgs ...interface{}) (interface{}, bool) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, ok := chanValue(t, ch, reflect.RecvDir, msgAndArgs...)
+	if !ok {
+		return nil, false
+	}
+	value, received, timedOut := receive(c, timeout)
+	if timedOut {
+		return nil, Fail(t, fmt.Sprintf("No value received within %s", timeout), msgAndArgs...)
+	}
+	if !received {
+		return nil, Fail(t, "Channel closed before a value was received", msgAndArgs...)
+	}
+	return value.Interface(), true
+}
+
+// ReceivesValue asserts that a value equal to expected is received from
+// the specified channel within timeout.
+//
+//	assert.ReceivesValue(t, results, 42, time.Second)
+func ReceivesValue(t TestingT, ch interface{}, expected interface{}, timeout time.Duration, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	actual, ok := Receives(t, ch, timeout, msgAndArgs...)
+	if !ok {
+		return false
+	}
+	if !ObjectsAreEqual(expected, actual) {
+		diff := diff(expected, actual)
+		expected, actual = formatUnequalValues(expected, actual)
+		return Fail(t, fmt.Sprintf("Received value not equal: \n"+
+			"expected: %s\n"+
+			"actual  : %s%s", expected, actual, diff), msgAndArgs...)
+	}
+	return true
+}
+
+// ChannelClosed asserts that the specified channel is closed, with no value
+// left to receive, within timeout.
+//
+//	assert.ChannelClosed(t, done, time.Second)
+func ChannelClosed(t TestingT, ch interface{}, timeout time.Duration, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, ok := chanValue(t, ch, reflect.RecvDir, msgAndArgs...)
+	if !ok {
+		return false
+	}
+	value, received, timedOut := receive(c, timeout)
+	if timedOut {
+		return Fail(t, fmt.Sprintf("Channel not closed within %s", timeout), msgAndArgs...)
+	}
+	if received {
+		return Fail(t, fmt.Sprintf("Channel not closed: received %s", truncatingFormat(value.Interface())), msgAndArgs...)
+	}
+	return true
+}
+
+// NoReceive asserts that no value is received from the specified channel,
+// and that it is not closed, for the given duration.
+//
+//	assert.NoReceive(t, events, 100*time.Millisecond)
+func NoReceive(t TestingT, ch interface{}, duration time.Duration, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, ok := chanValue(t, ch, reflect.RecvDir, msgAndArgs...)
+	if !ok {
+		return false
+	}
+	value, received, timedOut := receive(c, duration)
+	if timedOut {
+		return true
+	}
+	if !received {
+		return Fail(t, "Channel closed", msgAndArgs...)
+	}
+	return Fail(t, fmt.Sprintf("Received %s", truncatingFormat(value.Interface())), msgAndArgs...)
+}
+
+// Sends asserts that value can be sent on the specified channel within
+// timeout, and sends it.
+//
+//	assert.Sends(t, requests, req, time.Second)
+func Sends(t TestingT, ch interface{}, value interface{}, timeout time.Duration, msgAndArgs ...interface{}) (ok bool) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	c, ok := chanValue(t, ch, reflect.SendDir, msgAndArgs...)
+	if !ok {
+		return false
+	}
+	elemType := c.Type().Elem()
+	v := reflect.ValueOf(value)
+	if value == nil {
+		if !isNilKind(elemType.Kind()) {
+			return Fail(t, fmt.Sprintf("Cannot send nil on channel of %s", elemType), msgAndArgs...)
+		}
+		v = reflect.Zero(elemType)
+	} else if !v.Type().AssignableTo(elemType) {
+		return Fail(t, fmt.Sprintf("Cannot send %T on channel of %s", value, elemType), msgAndArgs...)
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	defer func() {
+		if r := recover(); r != nil {
+			// Sending on a closed channel panics.
+			ok = Fail(t, fmt.Sprintf("Cannot send %s: %v", truncatingFormat(value), r), msgAndArgs...)
+		}
+	}()
+	chosen, _, _ := reflect.Select([]reflect.SelectCase{
+		{Dir: reflect.SelectSend, Chan: c, Send: v},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(timer.C)},
+	})
+	if chosen == 1 {
+		return Fail(t, fmt.Sprintf("Could not send %s within %s", truncatingFormat(value), timeout), msgAndArgs...)
+	}
+	return true
+}
+
+// isNilKind reports whether values of kind k can be nil.
+func isNilKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice, reflect.UnsafePointer:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+package assert
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReceives(t *testing.T) {
+	ch := make(chan int, 1)
+	ch <- 42
+	v, ok := Receives(t, ch, time.Second)
+	True(t, ok)
+	Equal(t, 42, v)
+
+	var recvOnly <-chan int = ch
+	ch <- 43
+	v, ok = Receives(t, recvOnly, time.Second)
+	True(t, ok)
+	Equal(t, 43, v)
+
+	mockT := new(captureTestingT)
+	v, ok = Receives(mockT, ch, 10*time.Millisecond)
+	False(t, ok)
+	Nil(t, v)
+	Contains(t, mockT.msg, "No value received within 10ms")
+
+	close(ch)
+	_, ok = Receives(mockT, ch, time.Second)
+	False(t, ok)
+	Contains(t, mockT.msg, "Channel closed before a value was received")
+
+	_, ok = Receives(mockT, 42, time.Second)
+	False(t, ok)
+	Contains(t, mockT.msg, "42 is not a channel")
+
+	_, ok = Receives(mockT, make(chan<- int), time.Second)
+	False(t, ok)
+	Contains(t, mockT.msg, "Cannot receive from send-only channel chan<- int")
+
+	_, ok = Receives(mockT, (chan int)(nil), time.Second)
+	False(t, ok)
+	Contains(t, mockT.msg, "Channel is nil")
+}
+
+func TestReceivesValue(t *testing.T) {
+	ch := make(chan string, 2)
+	ch <- "hello"
+	ch <- "world"
+	True(t, ReceivesValue(t, ch, "hello", time.Second))
+
+	mockT := new(captureTestingT)
+	False(t, ReceivesValue(mockT, ch, "hello", time.Second))
+	Contains(t, mockT.msg, "Received value not equal: \n")
+	Contains(t, mockT.msg, `expected: "hello"`)
+	Contains(t, mockT.msg, `actual  : "world"`)
+
+	False(t, ReceivesValue(mockT, ch, "hello", 10*time.Millisecond))
+	Contains(t, mockT.msg, "No value received within 10ms")
+}
+
+func TestChannelClosed(t *testing.T) {
+	ch := make(chan struct{}, 1)
+	go close(ch)
+	True(t, ChannelClosed(t, ch, time.Second))
+
+	mockT := new(captureTestingT)
+	False(t, ChannelClosed(mockT, make(chan struct{}), 10*time.Millisecond))
+	Contains(t, mockT.msg, "Channel not closed within 10ms")
+
+	values := make(chan int, 1)
+	values <- 1
+	close(values)
+	False(t, ChannelClosed(mockT, values, time.Second))
+	Contains(t, mockT.msg, "Channel not closed: received 1")
+	True(t, ChannelClosed(t, values, time.Second))
+}
+
+func TestNoReceive(t *testing.T) {
+	ch := make(chan int, 1)
+	True(t, NoReceive(t, ch, 10*time.Millisecond))
+
+	mockT := new(captureTestingT)
+	ch <- 1
+	False(t, NoReceive(mockT, ch, time.Second))
+	Contains(t, mockT.msg, "Received 1")
+
+	close(ch)
+	False(t, NoReceive(mockT, ch, time.Second))
+	Contains(t, mockT.msg, "Channel closed")
+}
+
+func TestSends(t *testing.T) {
+	ch := make(chan interface{}, 1)
+	True(t, Sends(t, ch, "hello", time.Second))
+	Equal(t, "hello", <-ch)
+	True(t, Sends(t, ch, nil, time.Second))
+	Nil(t, <-ch)
+
+	mockT := new(captureTestingT)
+	ch <- "full"
+	False(t, Sends(mockT, ch, "hello", 10*time.Millisecond))
+	Contains(t, mockT.msg, `Could not send "hello" within 10ms`)
+
+	False(t, Sends(mockT, make(chan int, 1), "hello", time.Second))
+	Contains(t, mockT.msg, "Cannot send string on channel of int")
+
+	False(t, Sends(mockT, make(chan int, 1), nil, time.Second))
+	Contains(t, mockT.msg, "Cannot send nil on channel of int")
+
+	False(t, Sends(mockT, make(<-chan int), 1, time.Second))
+	Contains(t, mockT.msg, "Cannot send to receive-only channel <-chan int")
+
+	closed := make(chan int)
+	close(closed)
+	False(t, Sends(mockT, closed, 1, time.Second))
+	Contains(t, mockT.msg, "Cannot send 1: send on closed channel")
+}
@@ -3,11 +3,24 @@
 package assert
 
 import (
+	context "context"
+	clock "github.com/stretchr/testify/clock"
 	http "net/http"
 	url "net/url"
 	time "time"
 )
 
+// ChannelClosedf asserts that the specified channel is closed, with no value
+// left to receive, within timeout.
+//
+//	assert.ChannelClosedf(t, done, time.Second, "error message %s", "formatted")
+func ChannelClosedf(t TestingT, ch interface{}, timeout time.Duration, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return ChannelClosed(t, ch, timeout, append([]interface{}{msg}, args...)...)
+}
+
 // Conditionf uses a Comparison to assert a complex condition.
 func Conditionf(t TestingT, comp Comparison, msg string, args ...interface{}) bool {
 	if h, ok := t.(tHelper); ok {
@@ -169,6 +182,44 @@ func Eventuallyf(t TestingT, condition func() bool, waitFor time.Duration, tick
 	return Eventually(t, condition, waitFor, tick, append([]interface{}{msg}, args...)...)
 }
 
+// EventuallyClockf is like Eventually, but waits on clk instead of the real
+// clock, so that it can be driven by a clock.Fake.
+//
+//	assert.EventuallyClockf(t, fakeClock, func() bool { return true; }, time.Second, 10*time.Millisecond, "error message %s", "formatted")
+func EventuallyClockf(t TestingT, clk clock.Clock, condition func() bool, waitFor time.Duration, tick time.Duration, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return EventuallyClock(t, clk, condition, waitFor, tick, append([]interface{}{msg}, args...)...)
+}
+
+// EventuallyContextf asserts that given condition will be met before ctx is
+// done, checking it tick after the end of each attempt. The condition
+// receives ctx, to abort its work when ctx is done. The assertion waits
+// for the running condition to return before reporting.
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+//	defer cancel()
+//	assert.EventuallyContextf(t, ctx, func(ctx context.Context) bool { return ping(ctx) == nil }, 10*time.Millisecond, "error message %s", "formatted")
+func EventuallyContextf(t TestingT, ctx context.Context, condition func(ctx context.Context) bool, tick time.Duration, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return EventuallyContext(t, ctx, condition, tick, append([]interface{}{msg}, args...)...)
+}
+
+// EventuallyWithPollingf asserts that given condition will be met in waitFor
+// time, checking it as configured by polling, for example with exponential
+// backoff.
+//
+//	assert.EventuallyWithPollingf(t, func() bool { return true; }, time.Second, assert.Polling{Tick: time.Millisecond, Backoff: 2, Immediate: true}, "error message %s", "formatted")
+func EventuallyWithPollingf(t TestingT, condition func() bool, waitFor time.Duration, polling Polling, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return EventuallyWithPolling(t, condition, waitFor, polling, append([]interface{}{msg}, args...)...)
+}
+
 // EventuallyWithTf asserts that given condition will be met in waitFor time,
 // periodically checking target function each tick. In contrast to Eventually,
 // it supplies a CollectT to the condition function, so that the condition
@@ -194,6 +245,49 @@ func EventuallyWithTf(t TestingT, condition func(collect *CollectT), waitFor tim
 	return EventuallyWithT(t, condition, waitFor, tick, append([]interface{}{msg}, args...)...)
 }
 
+// EventuallyWithTClockf is like EventuallyWithT, but waits on clk instead
+// of the real clock, so that it can be driven by a clock.Fake.
+//
+//	assert.EventuallyWithTClockf(t, fakeClock, func(c *assert.CollectT, "error message %s", "formatted") {
+//		assert.True(c, externalValue, "expected 'externalValue' to be true")
+//	}, 10*time.Second, 1*time.Second)
+func EventuallyWithTClockf(t TestingT, clk clock.Clock, condition func(collect *CollectT), waitFor time.Duration, tick time.Duration, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return EventuallyWithTClock(t, clk, condition, waitFor, tick, append([]interface{}{msg}, args...)...)
+}
+
+// EventuallyWithTContextf is like EventuallyWithT, but checks the condition
+// until ctx is done instead of for a fixed duration. The condition
+// receives ctx, to abort its work when ctx is done. The assertion waits
+// for the running condition to return before reporting.
+//
+//	assert.EventuallyWithTContextf(t, ctx, func(ctx context.Context, c *assert.CollectT, "error message %s", "formatted") {
+//		resp, err := client.Get(ctx, key)
+//		if assert.NoError(c, err) {
+//			assert.Equal(c, "value", resp)
+//		}
+//	}, 10*time.Millisecond)
+func EventuallyWithTContextf(t TestingT, ctx context.Context, condition func(ctx context.Context, collect *CollectT), tick time.Duration, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return EventuallyWithTContext(t, ctx, condition, tick, append([]interface{}{msg}, args...)...)
+}
+
+// EventuallyWithValuef asserts that given condition will be met in waitFor
+// time, checking it as configured by polling. The condition also returns
+// the value it observed, which is reported if the condition is never met.
+//
+//	assert.EventuallyWithValuef(t, func() (bool, interface{}) { n := queue.Len(); return n == 0, n }, time.Second, assert.Polling{Tick: 10*time.Millisecond}, "error message %s", "formatted")
+func EventuallyWithValuef(t TestingT, condition func() (bool, interface{}), waitFor time.Duration, polling Polling, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return EventuallyWithValue(t, condition, waitFor, polling, append([]interface{}{msg}, args...)...)
+}
+
 // Exactlyf asserts that two objects are equal in value and type.
 //
 //	assert.Exactlyf(t, int32(123), int64(123), "error message %s", "formatted")
@@ -514,6 +608,30 @@ func Neverf(t TestingT, condition func() bool, waitFor time.Duration, tick time.
 	return Never(t, condition, waitFor, tick, append([]interface{}{msg}, args...)...)
 }
 
+// NeverClockf is like Never, but waits on clk instead of the real clock, so
+// that it can be driven by a clock.Fake.
+//
+//	assert.NeverClockf(t, fakeClock, func() bool { return false; }, time.Second, 10*time.Millisecond, "error message %s", "formatted")
+func NeverClockf(t TestingT, clk clock.Clock, condition func() bool, waitFor time.Duration, tick time.Duration, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return NeverClock(t, clk, condition, waitFor, tick, append([]interface{}{msg}, args...)...)
+}
+
+// NeverContextf asserts that the given condition is not met before ctx is
+// done, checking it tick after the end of each attempt. The condition
+// receives ctx, to abort its work when ctx is done. The assertion waits
+// for the running condition to return before reporting.
+//
+//	assert.NeverContextf(t, ctx, func(ctx context.Context) bool { return crashed(ctx) }, 10*time.Millisecond, "error message %s", "formatted")
+func NeverContextf(t TestingT, ctx context.Context, condition func(ctx context.Context) bool, tick time.Duration, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return NeverContext(t, ctx, condition, tick, append([]interface{}{msg}, args...)...)
+}
+
 // Nilf asserts that the specified object is nil.
 //
 //	assert.Nilf(t, err, "error message %s", "formatted")
@@ -555,6 +673,41 @@ func NoFileExistsf(t TestingT, path string, msg string, args ...interface{}) boo
 	return NoFileExists(t, path, append([]interface{}{msg}, args...)...)
 }
 
+// NoGoroutineLeaksf asserts that the goroutines started by calling f have
+// exited, waiting up to one second for them to do so.
+//
+//	assert.NoGoroutineLeaksf(t, func() { server.Shutdown(ctx) }, "error message %s", "formatted")
+func NoGoroutineLeaksf(t TestingT, f func(), msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return NoGoroutineLeaks(t, f, append([]interface{}{msg}, args...)...)
+}
+
+// NoGoroutineLeaksIgnoringf asserts that the goroutines started by calling f
+// have exited, waiting up to one second for them to do so, like
+// NoGoroutineLeaks. Goroutines whose stack contains one of the ignore
+// strings, typically the name of the function they run, are not reported.
+//
+//	assert.NoGoroutineLeaksIgnoringf(t, func() { client.Get(url) }, []string{"net/http.(*persistConn).readLoop"}, "error message %s", "formatted")
+func NoGoroutineLeaksIgnoringf(t TestingT, f func(), ignore []string, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return NoGoroutineLeaksIgnoring(t, f, ignore, append([]interface{}{msg}, args...)...)
+}
+
+// NoReceivef asserts that no value is received from the specified channel,
+// and that it is not closed, for the given duration.
+//
+//	assert.NoReceivef(t, events, 100*time.Millisecond, "error message %s", "formatted")
+func NoReceivef(t TestingT, ch interface{}, duration time.Duration, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return NoReceive(t, ch, duration, append([]interface{}{msg}, args...)...)
+}
+
 // NotContainsf asserts that the specified string, list(array, slice...) or map does NOT contain the
 // specified substring or element.
 //
@@ -758,6 +911,28 @@ func Positivef(t TestingT, e interface{}, msg string, args ...interface{}) bool
 	return Positive(t, e, append([]interface{}{msg}, args...)...)
 }
 
+// Receivesf asserts that a value is received from the specified channel
+// within timeout, and returns it.
+//
+//	assert.Receivesf(t, results, time.Second, "error message %s", "formatted")
+func Receivesf(t TestingT, ch interface{}, timeout time.Duration, msg string, args ...interface{}) (interface{}, bool) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return Receives(t, ch, timeout, append([]interface{}{msg}, args...)...)
+}
+
+// ReceivesValuef asserts that a value equal to expected is received from
+// the specified channel within timeout.
+//
+//	assert.ReceivesValuef(t, results, 42, time.Second, "error message %s", "formatted")
+func ReceivesValuef(t TestingT, ch interface{}, expected interface{}, timeout time.Duration, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return ReceivesValue(t, ch, expected, timeout, append([]interface{}{msg}, args...)...)
+}
+
 // Regexpf asserts that a specified regexp matches a string.
 //
 //	assert.Regexpf(t, regexp.MustCompile("start"), "it's starting", "error message %s", "formatted")
@@ -769,6 +944,84 @@ func Regexpf(t TestingT, rx interface{}, str interface{}, msg string, args ...in
 	return Regexp(t, rx, str, append([]interface{}{msg}, args...)...)
 }
 
+// ResponseBodyf asserts that the body of the response recorded by ServeHTTP
+// is the specified string.
+//
+//	assert.ResponseBodyf(t, resp, "pong", "error message %s", "formatted")
+func ResponseBodyf(t TestingT, resp *HTTPResponse, body string, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return ResponseBody(t, resp, body, append([]interface{}{msg}, args...)...)
+}
+
+// ResponseBodyContainsf asserts that the body of the response recorded by
+// ServeHTTP contains the specified substring.
+//
+//	assert.ResponseBodyContainsf(t, resp, "Welcome", "error message %s", "formatted")
+func ResponseBodyContainsf(t TestingT, resp *HTTPResponse, substr string, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return ResponseBodyContains(t, resp, substr, append([]interface{}{msg}, args...)...)
+}
+
+// ResponseContentTypef asserts that the media type of the response
+// recorded by ServeHTTP is the specified one, ignoring parameters such as
+// the charset.
+//
+//	assert.ResponseContentTypef(t, resp, "application/json", "error message %s", "formatted")
+func ResponseContentTypef(t TestingT, resp *HTTPResponse, mediaType string, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return ResponseContentType(t, resp, mediaType, append([]interface{}{msg}, args...)...)
+}
+
+// ResponseCookief asserts that the response recorded by ServeHTTP sets a
+// cookie with the specified name and value.
+//
+//	assert.ResponseCookief(t, resp, "session", "abc123", "error message %s", "formatted")
+func ResponseCookief(t TestingT, resp *HTTPResponse, name string, value string, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return ResponseCookie(t, resp, name, value, append([]interface{}{msg}, args...)...)
+}
+
+// ResponseHeaderf asserts that the response recorded by ServeHTTP has a
+// header with the specified key and value.
+//
+//	assert.ResponseHeaderf(t, resp, "Cache-Control", "no-store", "error message %s", "formatted")
+func ResponseHeaderf(t TestingT, resp *HTTPResponse, key string, value string, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return ResponseHeader(t, resp, key, value, append([]interface{}{msg}, args...)...)
+}
+
+// ResponseJSONEqf asserts that the body of the response recorded by
+// ServeHTTP is JSON equivalent to the specified JSON string, as JSONEq.
+//
+//	assert.ResponseJSONEqf(t, resp, `{"hello": "world", "foo": "bar"}`, "error message %s", "formatted")
+func ResponseJSONEqf(t TestingT, resp *HTTPResponse, expected string, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return ResponseJSONEq(t, resp, expected, append([]interface{}{msg}, args...)...)
+}
+
+// ResponseStatusf asserts that the response recorded by ServeHTTP has the
+// specified status code.
+//
+//	assert.ResponseStatusf(t, resp, http.StatusOK, "error message %s", "formatted")
+func ResponseStatusf(t TestingT, resp *HTTPResponse, statusCode int, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return ResponseStatus(t, resp, statusCode, append([]interface{}{msg}, args...)...)
+}
+
 // Samef asserts that two pointers reference the same object.
 //
 //	assert.Samef(t, ptr1, ptr2, "error message %s", "formatted")
@@ -782,6 +1035,17 @@ func Samef(t TestingT, expected interface{}, actual interface{}, msg string, arg
 	return Same(t, expected, actual, append([]interface{}{msg}, args...)...)
 }
 
+// Sendsf asserts that value can be sent on the specified channel within
+// timeout, and sends it.
+//
+//	assert.Sendsf(t, requests, req, time.Second, "error message %s", "formatted")
+func Sendsf(t TestingT, ch interface{}, value interface{}, timeout time.Duration, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return Sends(t, ch, value, timeout, append([]interface{}{msg}, args...)...)
+}
+
 // Subsetf asserts that the specified list(array, slice...) or map contains all
 // elements given in the specified subset list(array, slice...) or map.
 //
@@ -814,6 +1078,17 @@ func WithinDurationf(t TestingT, expected time.Time, actual time.Time, delta tim
 	return WithinDuration(t, expected, actual, delta, append([]interface{}{msg}, args...)...)
 }
 
+// WithinDurationClockf asserts that actual is within duration delta of the
+// current time of clk.
+//
+//	assert.WithinDurationClockf(t, fakeClock, createdAt, time.Second, "error message %s", "formatted")
+func WithinDurationClockf(t TestingT, clk clock.Clock, actual time.Time, delta time.Duration, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return WithinDurationClock(t, clk, actual, delta, append([]interface{}{msg}, args...)...)
+}
+
 // WithinRangef asserts that a time is within a time range (inclusive).
 //
 //	assert.WithinRangef(t, time.Now(), time.Now().Add(-time.Second), time.Now().Add(time.Second), "error message %s", "formatted")
@@ -0,0 +1,44 @@
+package assert
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/stretchr/testify/internal/goroutines"
+)
+
+// goroutineLeakGracePeriod is how long NoGoroutineLeaks waits for the
+// goroutines started by f to exit.
+const goroutineLeakGracePeriod = time.Second
+
+// NoGoroutineLeaks asserts that the goroutines started by calling f have
+// exited, waiting up to one second for them to do so.
+//
+//	assert.NoGoroutineLeaks(t, func() { server.Shutdown(ctx) })
+func NoGoroutineLeaks(t TestingT, f func(), msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return NoGoroutineLeaksIgnoring(t, f, nil, msgAndArgs...)
+}
+
+// NoGoroutineLeaksIgnoring asserts that the goroutines started by calling f
+// have exited, waiting up to one second for them to do so, like
+// NoGoroutineLeaks. Goroutines whose stack contains one of the ignore
+// strings, typically the name of the function they run, are not reported.
+//
+//	assert.NoGoroutineLeaksIgnoring(t, func() { client.Get(url) }, []string{"net/http.(*persistConn).readLoop"})
+func NoGoroutineLeaksIgnoring(t TestingT, f func(), ignore []string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	snapshot := goroutines.Take()
+	f()
+	leaked := snapshot.Leaked(goroutineLeakGracePeriod, ignore)
+	if len(leaked) == 0 {
+		return true
+	}
+	return Fail(t, fmt.Sprintf("Found %d leaked goroutine(s):\n%s", len(leaked), goroutines.Format(leaked)), msgAndArgs...)
+}
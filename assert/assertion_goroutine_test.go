@@ -0,0 +1,48 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNoGoroutineLeaks(t *testing.T) {
+	mockT := new(testing.T)
+	True(t, NoGoroutineLeaks(mockT, func() {}))
+
+	done := make(chan struct{})
+	True(t, NoGoroutineLeaks(mockT, func() {
+		go func() { close(done) }()
+	}))
+	<-done
+
+	release := make(chan struct{})
+	defer close(release)
+	captureT := new(captureTestingT)
+	False(t, NoGoroutineLeaks(captureT, func() {
+		go func() { <-release }()
+	}))
+	True(t, strings.Contains(captureT.msg, "Found 1 leaked goroutine(s):"), captureT.msg)
+	True(t, strings.Contains(captureT.msg, "TestNoGoroutineLeaks"), captureT.msg)
+}
+
+func TestNoGoroutineLeaksIgnoring(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	mockT := new(testing.T)
+	True(t, NoGoroutineLeaksIgnoring(mockT, func() {
+		go ignoredWorker(release)
+	}, []string{"ignoredWorker"}))
+
+	captureT := new(captureTestingT)
+	False(t, NoGoroutineLeaksIgnoring(captureT, func() {
+		go ignoredWorker(release)
+		go func() { <-release }()
+	}, []string{"ignoredWorker"}))
+	True(t, strings.Contains(captureT.msg, "Found 1 leaked goroutine(s):"), captureT.msg)
+	True(t, strings.Contains(captureT.msg, "TestNoGoroutineLeaksIgnoring"), captureT.msg)
+}
+
+func ignoredWorker(release chan struct{}) {
+	<-release
+}
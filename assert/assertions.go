@@ -3,6 +3,7 @@ package assert
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -19,6 +20,7 @@ import (
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/pmezard/go-difflib/difflib"
+	"github.com/stretchr/testify/clock"
 
 	// Wrapper around gopkg.in/yaml.v3
 	"github.com/stretchr/testify/assert/yaml"
@@ -1330,6 +1332,18 @@ func WithinDuration(t TestingT, expected, actual time.Time, delta time.Duration,
 	return true
 }
 
+// WithinDurationClock asserts that actual is within duration delta of the
+// current time of clk.
+//
+//	assert.WithinDurationClock(t, fakeClock, createdAt, time.Second)
+func WithinDurationClock(t TestingT, clk clock.Clock, actual time.Time, delta time.Duration, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return WithinDuration(t, clk.Now(), actual, delta, msgAndArgs...)
+}
+
 // WithinRange asserts that a time is within a time range (inclusive).
 //
 //	assert.WithinRange(t, time.Now(), time.Now().Add(-time.Second), time.Now().Add(time.Second))
@@ -1932,28 +1946,63 @@ func Eventually(t TestingT, condition func() bool, waitFor time.Duration, tick t
 		h.Helper()
 	}
 
-	ch := make(chan bool, 1)
+	return EventuallyClock(t, clock.New(), condition, waitFor, tick, msgAndArgs...)
+}
 
-	timer := time.NewTimer(waitFor)
-	defer timer.Stop()
+// EventuallyClock is like Eventually, but waits on clk instead of the real
+// clock, so that it can be driven by a clock.Fake.
+//
+//	assert.EventuallyClock(t, fakeClock, func() bool { return true; }, time.Second, 10*time.Millisecond)
+func EventuallyClock(t TestingT, clk clock.Clock, condition func() bool, waitFor time.Duration, tick time.Duration, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 
-	ticker := time.NewTicker(tick)
-	defer ticker.Stop()
+	result := pollFor(func(_ context.Context, attempt *pollAttempt) {
+		attempt.ok = condition()
+	}, waitFor, Polling{Tick: tick, Clock: clk, everyTick: true})
+	if !result.ok {
+		return Fail(t, result.failureMessage(false), msgAndArgs...)
+	}
+	return true
+}
 
-	for tick := ticker.C; ; {
-		select {
-		case <-timer.C:
-			return Fail(t, "Condition never satisfied", msgAndArgs...)
-		case <-tick:
-			tick = nil
-			go func() { ch <- condition() }()
-		case v := <-ch:
-			if v {
-				return true
-			}
-			tick = ticker.C
-		}
+// EventuallyWithPolling asserts that given condition will be met in waitFor
+// time, checking it as configured by polling, for example with exponential
+// backoff.
+//
+//	assert.EventuallyWithPolling(t, func() bool { return true; }, time.Second, assert.Polling{Tick: time.Millisecond, Backoff: 2, Immediate: true})
+func EventuallyWithPolling(t TestingT, condition func() bool, waitFor time.Duration, polling Polling, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	result := pollFor(func(_ context.Context, attempt *pollAttempt) {
+		attempt.ok = condition()
+	}, waitFor, polling)
+	if !result.ok {
+		return Fail(t, result.failureMessage(false), msgAndArgs...)
+	}
+	return true
+}
+
+// EventuallyWithValue asserts that given condition will be met in waitFor
+// time, checking it as configured by polling. The condition also returns
+// the value it observed, which is reported if the condition is never met.
+//
+//	assert.EventuallyWithValue(t, func() (bool, interface{}) { n := queue.Len(); return n == 0, n }, time.Second, assert.Polling{Tick: 10*time.Millisecond})
+func EventuallyWithValue(t TestingT, condition func() (bool, interface{}), waitFor time.Duration, polling Polling, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
 	}
+
+	result := pollFor(func(_ context.Context, attempt *pollAttempt) {
+		attempt.ok, attempt.value = condition()
+	}, waitFor, polling)
+	if !result.ok {
+		return Fail(t, result.failureMessage(true), msgAndArgs...)
+	}
+	return true
 }
 
 // CollectT implements the TestingT interface and collects all errors.
@@ -2018,40 +2067,27 @@ func EventuallyWithT(t TestingT, condition func(collect *CollectT), waitFor time
 		h.Helper()
 	}
 
-	var lastFinishedTickErrs []error
-	ch := make(chan *CollectT, 1)
-
-	timer := time.NewTimer(waitFor)
-	defer timer.Stop()
+	return EventuallyWithTClock(t, clock.New(), condition, waitFor, tick, msgAndArgs...)
+}
 
-	ticker := time.NewTicker(tick)
-	defer ticker.Stop()
+// EventuallyWithTClock is like EventuallyWithT, but waits on clk instead
+// of the real clock, so that it can be driven by a clock.Fake.
+//
+//	assert.EventuallyWithTClock(t, fakeClock, func(c *assert.CollectT) {
+//		assert.True(c, externalValue, "expected 'externalValue' to be true")
+//	}, 10*time.Second, 1*time.Second)
+func EventuallyWithTClock(t TestingT, clk clock.Clock, condition func(collect *CollectT), waitFor time.Duration, tick time.Duration, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 
-	for tick := ticker.C; ; {
-		select {
-		case <-timer.C:
-			for _, err := range lastFinishedTickErrs {
-				t.Errorf("%v", err)
-			}
-			return Fail(t, "Condition never satisfied", msgAndArgs...)
-		case <-tick:
-			tick = nil
-			go func() {
-				collect := new(CollectT)
-				defer func() {
-					ch <- collect
-				}()
-				condition(collect)
-			}()
-		case collect := <-ch:
-			if !collect.failed() {
-				return true
-			}
-			// Keep the errors from the last ended condition, so that they can be copied to t if timeout is reached.
-			lastFinishedTickErrs = collect.errors
-			tick = ticker.C
-		}
+	result := pollFor(collectAttempt(func(_ context.Context, collect *CollectT) {
+		condition(collect)
+	}), waitFor, Polling{Tick: tick, Clock: clk, everyTick: true})
+	if !result.ok {
+		return failCollected(t, result, msgAndArgs...)
 	}
+	return true
 }
 
 // Never asserts that the given condition doesn't satisfy in waitFor time,
@@ -2063,28 +2099,90 @@ func Never(t TestingT, condition func() bool, waitFor time.Duration, tick time.D
 		h.Helper()
 	}
 
-	ch := make(chan bool, 1)
+	return NeverClock(t, clock.New(), condition, waitFor, tick, msgAndArgs...)
+}
 
-	timer := time.NewTimer(waitFor)
-	defer timer.Stop()
+// NeverClock is like Never, but waits on clk instead of the real clock, so
+// that it can be driven by a clock.Fake.
+//
+//	assert.NeverClock(t, fakeClock, func() bool { return false; }, time.Second, 10*time.Millisecond)
+func NeverClock(t TestingT, clk clock.Clock, condition func() bool, waitFor time.Duration, tick time.Duration, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 
-	ticker := time.NewTicker(tick)
-	defer ticker.Stop()
+	result := pollFor(func(_ context.Context, attempt *pollAttempt) {
+		attempt.ok = condition()
+	}, waitFor, Polling{Tick: tick, Clock: clk, everyTick: true})
+	if result.ok {
+		return Fail(t, "Condition satisfied", msgAndArgs...)
+	}
+	return true
+}
 
-	for tick := ticker.C; ; {
-		select {
-		case <-timer.C:
-			return true
-		case <-tick:
-			tick = nil
-			go func() { ch <- condition() }()
-		case v := <-ch:
-			if v {
-				return Fail(t, "Condition satisfied", msgAndArgs...)
-			}
-			tick = ticker.C
-		}
+// EventuallyContext asserts that given condition will be met before ctx is
+// done, checking it tick after the end of each attempt. The condition
+// receives ctx, to abort its work when ctx is done. The assertion waits
+// for the running condition to return before reporting.
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+//	defer cancel()
+//	assert.EventuallyContext(t, ctx, func(ctx context.Context) bool { return ping(ctx) == nil }, 10*time.Millisecond)
+func EventuallyContext(t TestingT, ctx context.Context, condition func(ctx context.Context) bool, tick time.Duration, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	result := poll(ctx, func(ctx context.Context, attempt *pollAttempt) {
+		attempt.ok = condition(ctx)
+	}, nil, Polling{Tick: tick})
+	if !result.ok {
+		return Fail(t, result.failureMessage(false), msgAndArgs...)
+	}
+	return true
+}
+
+// EventuallyWithTContext is like EventuallyWithT, but checks the condition
+// until ctx is done instead of for a fixed duration. The condition
+// receives ctx, to abort its work when ctx is done. The assertion waits
+// for the running condition to return before reporting.
+//
+//	assert.EventuallyWithTContext(t, ctx, func(ctx context.Context, c *assert.CollectT) {
+//		resp, err := client.Get(ctx, key)
+//		if assert.NoError(c, err) {
+//			assert.Equal(c, "value", resp)
+//		}
+//	}, 10*time.Millisecond)
+func EventuallyWithTContext(t TestingT, ctx context.Context, condition func(ctx context.Context, collect *CollectT), tick time.Duration, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	result := poll(ctx, collectAttempt(condition), nil, Polling{Tick: tick})
+	if !result.ok {
+		return failCollected(t, result, msgAndArgs...)
 	}
+	return true
+}
+
+// NeverContext asserts that the given condition is not met before ctx is
+// done, checking it tick after the end of each attempt. The condition
+// receives ctx, to abort its work when ctx is done. The assertion waits
+// for the running condition to return before reporting.
+//
+//	assert.NeverContext(t, ctx, func(ctx context.Context) bool { return crashed(ctx) }, 10*time.Millisecond)
+func NeverContext(t TestingT, ctx context.Context, condition func(ctx context.Context) bool, tick time.Duration, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	result := poll(ctx, func(ctx context.Context, attempt *pollAttempt) {
+		attempt.ok = condition(ctx)
+	}, nil, Polling{Tick: tick})
+	if result.ok {
+		return Fail(t, "Condition satisfied", msgAndArgs...)
+	}
+	return true
 }
 
 // ErrorIs asserts that at least one of the errors in err's chain matches target.
@@ -16,6 +16,8 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/stretchr/testify/clock"
 )
 
 var (
@@ -1861,6 +1863,16 @@ func TestWithinDuration(t *testing.T) {
 	False(t, WithinDuration(mockT, b, a, -11*time.Second), "A 10s difference is not within a 9s time difference")
 }
 
+func TestWithinDurationClock(t *testing.T) {
+	mockT := new(testing.T)
+	fake := clock.NewFake(time.Now())
+	a := fake.Now()
+	fake.Advance(10 * time.Second)
+
+	True(t, WithinDurationClock(mockT, fake, a, 10*time.Second), "A 10s difference is within a 10s time difference")
+	False(t, WithinDurationClock(mockT, fake, a, 9*time.Second), "A 10s difference is not within a 9s time difference")
+}
+
 func TestWithinRange(t *testing.T) {
 
 	mockT := new(testing.T)
@@ -2976,6 +2988,44 @@ func TestEventuallyTrue(t *testing.T) {
 	True(t, Eventually(t, condition, 100*time.Millisecond, 20*time.Millisecond))
 }
 
+// advanceOnCall advances fake by tick n times, each time once the timers of
+// a polling assertion are waiting, then waits for the condition to report
+// being called on calls.
+func advanceOnCall(fake *clock.Fake, tick time.Duration, calls <-chan struct{}, n int) {
+	for i := 0; i < n; i++ {
+		fake.BlockUntil(2)
+		fake.Advance(tick)
+		<-calls
+	}
+}
+
+func TestEventuallyClock(t *testing.T) {
+	fake := clock.NewFake(time.Now())
+	start := fake.Now()
+	calls := make(chan struct{})
+	state := 0
+	condition := func() bool {
+		state++
+		calls <- struct{}{}
+		return state == 3
+	}
+	go advanceOnCall(fake, time.Second, calls, 3)
+
+	True(t, EventuallyClock(t, fake, condition, time.Hour, time.Second))
+	Equal(t, 3*time.Second, fake.Since(start))
+}
+
+func TestEventuallyClockFalse(t *testing.T) {
+	mockT := new(testing.T)
+	fake := clock.NewFake(time.Now())
+	go func() {
+		fake.BlockUntil(2)
+		fake.Advance(time.Hour)
+	}()
+
+	False(t, EventuallyClock(mockT, fake, func() bool { return false }, time.Minute, time.Second))
+}
+
 // errorsCapturingT is a mock implementation of TestingT that captures errors reported with Errorf.
 type errorsCapturingT struct {
 	errors []error
@@ -3012,6 +3062,29 @@ func TestEventuallyWithTTrue(t *testing.T) {
 	Equal(t, 2, counter, "Condition is expected to be called 2 times")
 }
 
+func TestEventuallyWithTClock(t *testing.T) {
+	mockT := new(errorsCapturingT)
+	fake := clock.NewFake(time.Now())
+	calls := make(chan struct{})
+	counter := 0
+	condition := func(collect *CollectT) {
+		counter++
+		calls <- struct{}{}
+		True(collect, counter == 2)
+	}
+	go advanceOnCall(fake, time.Second, calls, 2)
+
+	True(t, EventuallyWithTClock(mockT, fake, condition, time.Hour, time.Second))
+	Len(t, mockT.errors, 0)
+
+	fake = clock.NewFake(time.Now())
+	go func() {
+		fake.BlockUntil(2)
+		fake.Advance(time.Hour)
+	}()
+	False(t, EventuallyWithTClock(mockT, fake, func(collect *CollectT) {}, time.Minute, time.Second))
+}
+
 func TestEventuallyWithT_ConcurrencySafe(t *testing.T) {
 	mockT := new(errorsCapturingT)
 
@@ -3085,6 +3158,27 @@ func TestNeverTrue(t *testing.T) {
 	False(t, Never(mockT, condition, 100*time.Millisecond, 20*time.Millisecond))
 }
 
+func TestNeverClock(t *testing.T) {
+	fake := clock.NewFake(time.Now())
+	go func() {
+		fake.BlockUntil(2)
+		fake.Advance(time.Hour)
+	}()
+	True(t, NeverClock(t, fake, func() bool { return false }, time.Minute, time.Second))
+
+	mockT := new(testing.T)
+	fake = clock.NewFake(time.Now())
+	calls := make(chan struct{})
+	state := 0
+	condition := func() bool {
+		state++
+		calls <- struct{}{}
+		return state == 2
+	}
+	go advanceOnCall(fake, time.Second, calls, 2)
+	False(t, NeverClock(mockT, fake, condition, time.Hour, time.Second))
+}
+
 // Check that a long running condition doesn't block Eventually.
 // See issue 805 (and its long tail of following issues)
 func TestEventuallyTimeout(t *testing.T) {
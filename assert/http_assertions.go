@@ -1,9 +1,13 @@
 package assert
 
 import (
+	"bytes"
 	"fmt"
+	"io"
+	"mime"
 	"net/http"
 	"net/http/httptest"
+	"net/http/httputil"
 	"net/url"
 	"strings"
 )
@@ -163,3 +167,165 @@ func HTTPBodyNotContains(t TestingT, handler http.HandlerFunc, method, url strin
 
 	return !contains
 }
+
+// HTTPResponse is the response of an http.Handler to a request, returned
+// by ServeHTTP to check it with assertions such as ResponseStatus and
+// ResponseJSONEq. Failing assertions report the request and the full
+// response.
+type HTTPResponse struct {
+	// Request is the request served by the handler.
+	Request *http.Request
+	// Response is the response written by the handler. Its body has been
+	// read into Body.
+	Response *http.Response
+	// Body is the body of the response.
+	Body []byte
+}
+
+// ServeHTTP calls handler once with req, and records its response. Use
+// httptest.NewRequest to build requests with a body, headers or cookies.
+//
+//	req := httptest.NewRequest("POST", "/users", strings.NewReader(`{"name": "gopher"}`))
+//	req.Header.Set("Content-Type", "application/json")
+//	resp := assert.ServeHTTP(router, req)
+//	assert.ResponseStatus(t, resp, http.StatusCreated)
+//	assert.ResponseJSONEq(t, resp, `{"id": 1, "name": "gopher"}`)
+func ServeHTTP(handler http.Handler, req *http.Request) *HTTPResponse {
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	resp := w.Result()
+	body := w.Body.Bytes()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return &HTTPResponse{Request: req, Response: resp, Body: body}
+}
+
+// String returns the request line and the dump of the response.
+func (r *HTTPResponse) String() string {
+	r.Response.Body = io.NopCloser(bytes.NewReader(r.Body))
+	dump, err := httputil.DumpResponse(r.Response, true)
+	if err != nil {
+		dump = []byte(fmt.Sprintf("cannot dump response: %s", err))
+	}
+	return fmt.Sprintf("Request: %s %s\nResponse:\n%s", r.Request.Method, r.Request.URL, dump)
+}
+
+// msgAndArgs returns msgAndArgs with the description of r appended to the
+// message.
+func (r *HTTPResponse) msgAndArgs(msgAndArgs []interface{}) []interface{} {
+	msg := messageFromMsgAndArgs(msgAndArgs...)
+	if msg != "" {
+		msg += "\n"
+	}
+	return []interface{}{msg + r.String()}
+}
+
+// ResponseStatus asserts that the response recorded by ServeHTTP has the
+// specified status code.
+//
+//	assert.ResponseStatus(t, resp, http.StatusOK)
+func ResponseStatus(t TestingT, resp *HTTPResponse, statusCode int, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if resp.Response.StatusCode != statusCode {
+		return Fail(t, fmt.Sprintf("Expected HTTP status code %d but received %d", statusCode, resp.Response.StatusCode), resp.msgAndArgs(msgAndArgs)...)
+	}
+	return true
+}
+
+// ResponseHeader asserts that the response recorded by ServeHTTP has a
+// header with the specified key and value.
+//
+//	assert.ResponseHeader(t, resp, "Cache-Control", "no-store")
+func ResponseHeader(t TestingT, resp *HTTPResponse, key, value string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	values := resp.Response.Header.Values(key)
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	if len(values) == 0 {
+		return Fail(t, fmt.Sprintf("Expected HTTP header %q to be %q but it is not set", key, value), resp.msgAndArgs(msgAndArgs)...)
+	}
+	return Fail(t, fmt.Sprintf("Expected HTTP header %q to be %q but received %q", key, value, values), resp.msgAndArgs(msgAndArgs)...)
+}
+
+// ResponseCookie asserts that the response recorded by ServeHTTP sets a
+// cookie with the specified name and value.
+//
+//	assert.ResponseCookie(t, resp, "session", "abc123")
+func ResponseCookie(t TestingT, resp *HTTPResponse, name, value string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	for _, cookie := range resp.Response.Cookies() {
+		if cookie.Name != name {
+			continue
+		}
+		if cookie.Value != value {
+			return Fail(t, fmt.Sprintf("Expected cookie %q to be %q but received %q", name, value, cookie.Value), resp.msgAndArgs(msgAndArgs)...)
+		}
+		return true
+	}
+	return Fail(t, fmt.Sprintf("Expected cookie %q to be %q but it is not set", name, value), resp.msgAndArgs(msgAndArgs)...)
+}
+
+// ResponseContentType asserts that the media type of the response
+// recorded by ServeHTTP is the specified one, ignoring parameters such as
+// the charset.
+//
+//	assert.ResponseContentType(t, resp, "application/json")
+func ResponseContentType(t TestingT, resp *HTTPResponse, mediaType string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	contentType := resp.Response.Header.Get("Content-Type")
+	actual, _, err := mime.ParseMediaType(contentType)
+	if err != nil || !strings.EqualFold(actual, mediaType) {
+		return Fail(t, fmt.Sprintf("Expected content type %q but received %q", mediaType, contentType), resp.msgAndArgs(msgAndArgs)...)
+	}
+	return true
+}
+
+// ResponseBody asserts that the body of the response recorded by ServeHTTP
+// is the specified string.
+//
+//	assert.ResponseBody(t, resp, "pong")
+func ResponseBody(t TestingT, resp *HTTPResponse, body string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return Equal(t, body, string(resp.Body), resp.msgAndArgs(msgAndArgs)...)
+}
+
+// ResponseBodyContains asserts that the body of the response recorded by
+// ServeHTTP contains the specified substring.
+//
+//	assert.ResponseBodyContains(t, resp, "Welcome")
+func ResponseBodyContains(t TestingT, resp *HTTPResponse, substr string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return Contains(t, string(resp.Body), substr, resp.msgAndArgs(msgAndArgs)...)
+}
+
+// ResponseJSONEq asserts that the body of the response recorded by
+// ServeHTTP is JSON equivalent to the specified JSON string, as JSONEq.
+//
+//	assert.ResponseJSONEq(t, resp, `{"hello": "world", "foo": "bar"}`)
+func ResponseJSONEq(t TestingT, resp *HTTPResponse, expected string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	return JSONEq(t, expected, string(resp.Body), resp.msgAndArgs(msgAndArgs)...)
+}
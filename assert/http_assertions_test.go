@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 )
 
@@ -212,3 +214,85 @@ func TestHttpBodyWrappers(t *testing.T) {
 	assert.False(mockAssert.HTTPBodyNotContains(httpHelloName, "GET", "/", url.Values{"name": []string{"World"}}, "World"))
 	assert.True(mockAssert.HTTPBodyNotContains(httpHelloName, "GET", "/", url.Values{"name": []string{"World"}}, "world"))
 }
+
+// usersHandler creates a user from a JSON body on POST /users, for
+// authenticated requests.
+var usersHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.URL.Path != "/users" {
+		http.NotFound(w, r)
+		return
+	}
+	if c, err := r.Cookie("session"); err != nil || c.Value != "secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	body, _ := io.ReadAll(r.Body)
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Location", "/users/1")
+	http.SetCookie(w, &http.Cookie{Name: "last", Value: "1"})
+	w.WriteHeader(http.StatusCreated)
+	fmt.Fprintf(w, `{"id": 1, "user": %s}`, body)
+})
+
+func newUserRequest() *http.Request {
+	req := httptest.NewRequest("POST", "/users", strings.NewReader(`{"name": "gopher"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.AddCookie(&http.Cookie{Name: "session", Value: "secret"})
+	return req
+}
+
+func TestServeHTTP(t *testing.T) {
+	resp := ServeHTTP(usersHandler, newUserRequest())
+
+	True(t, ResponseStatus(t, resp, http.StatusCreated))
+	True(t, ResponseHeader(t, resp, "Location", "/users/1"))
+	True(t, ResponseCookie(t, resp, "last", "1"))
+	True(t, ResponseContentType(t, resp, "application/json"))
+	True(t, ResponseBody(t, resp, `{"id": 1, "user": {"name": "gopher"}}`))
+	True(t, ResponseBodyContains(t, resp, "gopher"))
+	True(t, ResponseJSONEq(t, resp, `{"user": {"name": "gopher"}, "id": 1}`))
+
+	mockT := new(mockTestingT)
+	False(t, ResponseStatus(mockT, resp, http.StatusOK, "creating %s", "gopher"))
+	Contains(t, mockT.errorString(), "Expected HTTP status code 200 but received 201")
+	Contains(t, mockT.errorString(), "creating gopher")
+	Contains(t, mockT.errorString(), "Request: POST /users")
+	Contains(t, mockT.errorString(), "HTTP/1.1 201 Created")
+	Contains(t, mockT.errorString(), `{"id": 1, "user": {"name": "gopher"}}`)
+
+	False(t, ResponseHeader(mockT, resp, "Location", "/users/2"))
+	Contains(t, mockT.errorString(), `Expected HTTP header "Location" to be "/users/2" but received ["/users/1"]`)
+	False(t, ResponseHeader(mockT, resp, "ETag", "abc"))
+	Contains(t, mockT.errorString(), `Expected HTTP header "ETag" to be "abc" but it is not set`)
+
+	False(t, ResponseCookie(mockT, resp, "last", "2"))
+	Contains(t, mockT.errorString(), `Expected cookie "last" to be "2" but received "1"`)
+	False(t, ResponseCookie(mockT, resp, "session", "secret"))
+	Contains(t, mockT.errorString(), `Expected cookie "session" to be "secret" but it is not set`)
+
+	False(t, ResponseContentType(mockT, resp, "text/plain"))
+	Contains(t, mockT.errorString(), `Expected content type "text/plain" but received "application/json; charset=utf-8"`)
+
+	False(t, ResponseBody(mockT, resp, "{}"))
+	Contains(t, mockT.errorString(), "Not equal")
+	False(t, ResponseBodyContains(mockT, resp, "rustacean"))
+	Contains(t, mockT.errorString(), "does not contain")
+	False(t, ResponseJSONEq(mockT, resp, `{"id": 2}`))
+	Contains(t, mockT.errorString(), "Not equal")
+	Contains(t, mockT.errorString(), "Request: POST /users")
+
+	unauthorized := newUserRequest()
+	unauthorized.Header.Del("Cookie")
+	True(t, ResponseStatus(t, ServeHTTP(usersHandler, unauthorized), http.StatusUnauthorized))
+}
+
+func TestServeHTTPWrappers(t *testing.T) {
+	assert := New(t)
+	mockAssert := New(new(testing.T))
+	resp := ServeHTTP(usersHandler, newUserRequest())
+
+	assert.True(mockAssert.ResponseStatus(resp, http.StatusCreated))
+	assert.False(mockAssert.ResponseStatus(resp, http.StatusOK))
+	assert.True(mockAssert.ResponseJSONEq(resp, `{"id": 1, "user": {"name": "gopher"}}`))
+	assert.False(mockAssert.ResponseJSONEq(resp, `{}`))
+}
@@ -0,0 +1,240 @@
+package assert
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/stretchr/testify/clock"
+)
+
+// Polling configures how EventuallyWithPolling and EventuallyWithValue
+// check their condition.
+type Polling struct {
+	// Tick is the delay between the end of an attempt and the start of
+	// the next one, and before the first attempt unless Immediate is set.
+	Tick time.Duration
+
+	// Backoff multiplies the delay after each attempt, for exponential
+	// backoff. Values lower than or equal to 1 keep the delay constant.
+	Backoff float64
+
+	// MaxTick caps the delay grown by Backoff. Zero means no cap.
+	MaxTick time.Duration
+
+	// Jitter randomizes each delay by up to this fraction of it, for
+	// example 0.1 for ±10%.
+	Jitter float64
+
+	// MaxAttempts stops polling after this many failed attempts, before
+	// the wait time elapses. Zero means no limit.
+	MaxAttempts int
+
+	// Immediate makes the first attempt start right away instead of
+	// after Tick.
+	Immediate bool
+
+	// Clock measures the wait time and the delays. Nil means the real
+	// clock.
+	Clock clock.Clock
+
+	// everyTick makes attempts start on the ticks of a ticker of period
+	// Tick, as Eventually, EventuallyWithT and Never always did, rather
+	// than Tick after the end of the previous attempt. The other fields
+	// but Clock are ignored.
+	everyTick bool
+}
+
+func (p Polling) clock() clock.Clock {
+	if p.Clock == nil {
+		return clock.New()
+	}
+	return p.Clock
+}
+
+// delay returns the delay after n failed attempts.
+func (p Polling) delay(n int) time.Duration {
+	d := float64(p.Tick)
+	if p.Backoff > 1 {
+		for i := 1; i < n && (p.MaxTick <= 0 || d < float64(p.MaxTick)); i++ {
+			d *= p.Backoff
+		}
+		if p.MaxTick > 0 && d > float64(p.MaxTick) {
+			d = float64(p.MaxTick)
+		}
+	}
+	if p.Jitter > 0 {
+		d += (2*rand.Float64() - 1) * p.Jitter * d
+	}
+	return time.Duration(d)
+}
+
+// pollAttempt is the outcome of a call of the condition of a polling
+// assertion.
+type pollAttempt struct {
+	ok    bool
+	value interface{}
+}
+
+// pollResult describes the attempts made by poll.
+type pollResult struct {
+	ok       bool
+	attempts int
+	elapsed  time.Duration
+	// running is true if the last attempt had not returned when the wait
+	// time elapsed.
+	running bool
+	// exhausted is true if polling stopped after MaxAttempts attempts.
+	exhausted bool
+	// err is the error of the context if polling stopped because it was
+	// done.
+	err error
+	// last is the outcome of the last attempt that returned, if any.
+	last *pollAttempt
+}
+
+// pollFor polls condition as configured by p until waitFor elapses.
+func pollFor(condition func(ctx context.Context, attempt *pollAttempt), waitFor time.Duration, p Polling) pollResult {
+	timer := p.clock().NewTimer(waitFor)
+	defer timer.Stop()
+	return poll(context.Background(), condition, timer.C(), p)
+}
+
+// poll calls condition, each time in a new goroutine, as configured by p
+// until an attempt succeeds, p.MaxAttempts attempts fail, timeout fires or
+// ctx is done. An attempt succeeds if condition sets the ok field of its
+// argument. Attempts exiting with runtime.Goexit fail.
+//
+// When timeout fires, poll returns without waiting for the running
+// attempt. When ctx is done, which the condition can observe, poll waits
+// for the running attempt to return.
+func poll(ctx context.Context, condition func(ctx context.Context, attempt *pollAttempt), timeout <-chan time.Time, p Polling) pollResult {
+	clk := p.clock()
+	start := clk.Now()
+
+	var result pollResult
+	ch := make(chan *pollAttempt, 1)
+	run := func() {
+		result.attempts++
+		result.running = true
+		go func() {
+			attempt := new(pollAttempt)
+			defer func() { ch <- attempt }()
+			condition(ctx, attempt)
+		}()
+	}
+
+	// nextTick returns a channel receiving when the attempt following n
+	// failed attempts must start.
+	var nextTick func(n int) <-chan time.Time
+	if p.everyTick {
+		ticker := clk.NewTicker(p.Tick)
+		defer ticker.Stop()
+		nextTick = func(int) <-chan time.Time { return ticker.C() }
+	} else {
+		var next clock.Timer
+		defer func() {
+			if next != nil {
+				next.Stop()
+			}
+		}()
+		nextTick = func(n int) <-chan time.Time {
+			if next == nil {
+				next = clk.NewTimer(p.delay(n + 1))
+			} else {
+				next.Reset(p.delay(n + 1))
+			}
+			return next.C()
+		}
+	}
+
+	var tick <-chan time.Time
+	if p.Immediate && !p.everyTick {
+		run()
+	} else {
+		tick = nextTick(0)
+	}
+
+	done := ctx.Done()
+	for {
+		select {
+		case <-timeout:
+			result.elapsed = clk.Since(start)
+			return result
+		case <-done:
+			result.err = ctx.Err()
+			if result.running {
+				result.running = false
+				result.last = <-ch
+				result.ok = result.last.ok
+			}
+			result.elapsed = clk.Since(start)
+			return result
+		case <-tick:
+			tick = nil
+			run()
+		case attempt := <-ch:
+			result.running = false
+			result.last = attempt
+			if attempt.ok {
+				result.ok = true
+				return result
+			}
+			if p.MaxAttempts > 0 && result.attempts >= p.MaxAttempts {
+				result.exhausted = true
+				result.elapsed = clk.Since(start)
+				return result
+			}
+			tick = nextTick(result.attempts)
+		}
+	}
+}
+
+// collectAttempt returns a condition for poll calling condition with a
+// new CollectT, which is the value of the attempt.
+func collectAttempt(condition func(ctx context.Context, collect *CollectT)) func(ctx context.Context, attempt *pollAttempt) {
+	return func(ctx context.Context, attempt *pollAttempt) {
+		collect := new(CollectT)
+		defer func() {
+			attempt.ok = !collect.failed()
+			attempt.value = collect
+		}()
+		condition(ctx, collect)
+	}
+}
+
+// failCollected copies the errors of the last attempt of a condition built
+// by collectAttempt to t, and fails.
+func failCollected(t TestingT, result pollResult, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if result.last != nil {
+		for _, err := range result.last.value.(*CollectT).errors {
+			t.Errorf("%v", err)
+		}
+	}
+	return Fail(t, result.failureMessage(false), msgAndArgs...)
+}
+
+// failureMessage describes the attempts of a polling assertion that was
+// never satisfied. If withValue is set, it includes the value returned by
+// the last attempt.
+func (r pollResult) failureMessage(withValue bool) string {
+	msg := fmt.Sprintf("Condition never satisfied after %d attempt(s) in %s", r.attempts, r.elapsed)
+	if r.exhausted {
+		msg += " (maximum attempts reached)"
+	}
+	if r.err != nil {
+		msg += "\nContext done: " + r.err.Error()
+	}
+	if r.running {
+		msg += "\nLast attempt still running"
+	}
+	if withValue && r.last != nil {
+		msg += "\nLast value: " + truncatingFormat(r.last.value)
+	}
+	return msg
+}
@@ -0,0 +1,194 @@
+package assert
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/clock"
+)
+
+func TestPollingDelay(t *testing.T) {
+	fixed := Polling{Tick: time.Second}
+	Equal(t, time.Second, fixed.delay(1))
+	Equal(t, time.Second, fixed.delay(10))
+
+	backoff := Polling{Tick: time.Second, Backoff: 2, MaxTick: 10 * time.Second}
+	Equal(t, time.Second, backoff.delay(1))
+	Equal(t, 2*time.Second, backoff.delay(2))
+	Equal(t, 8*time.Second, backoff.delay(4))
+	Equal(t, 10*time.Second, backoff.delay(5))
+	Equal(t, 10*time.Second, backoff.delay(1000))
+
+	jitter := Polling{Tick: time.Second, Jitter: 0.1}
+	for i := 0; i < 100; i++ {
+		d := jitter.delay(1)
+		True(t, d >= 900*time.Millisecond && d <= 1100*time.Millisecond, "delay %s out of range", d)
+	}
+}
+
+func TestEventuallyWithPollingImmediate(t *testing.T) {
+	fake := clock.NewFake(time.Now())
+	start := fake.Now()
+
+	// Nothing advances the fake clock: only an immediate attempt can run.
+	True(t, EventuallyWithPolling(t, func() bool { return true }, time.Second, Polling{
+		Tick:      time.Second,
+		Immediate: true,
+		Clock:     fake,
+	}))
+	Equal(t, start, fake.Now())
+}
+
+func TestEventuallyWithPollingBackoff(t *testing.T) {
+	fake := clock.NewFake(time.Now())
+	start := fake.Now()
+	calls := make(chan struct{})
+	go func() {
+		for _, d := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second} {
+			fake.BlockUntil(2)
+			fake.Advance(d)
+			<-calls
+		}
+	}()
+
+	n := 0
+	True(t, EventuallyWithPolling(t, func() bool {
+		n++
+		calls <- struct{}{}
+		return n == 3
+	}, time.Hour, Polling{Tick: time.Second, Backoff: 2, Clock: fake}))
+	Equal(t, 7*time.Second, fake.Since(start))
+}
+
+func TestEventuallyClockEveryTick(t *testing.T) {
+	fake := clock.NewFake(time.Now())
+	start := fake.Now()
+	go func() {
+		fake.BlockUntil(2)
+		fake.Advance(time.Second)
+	}()
+
+	// Attempts start on the ticks of a ticker: the tick missed by the
+	// slow first attempt starts the second one as soon as it ends.
+	done := make(chan bool)
+	go func() {
+		n := 0
+		done <- EventuallyClock(t, fake, func() bool {
+			n++
+			if n == 1 {
+				fake.Advance(1500 * time.Millisecond)
+			}
+			return n == 2
+		}, time.Hour, time.Second)
+	}()
+	select {
+	case ok := <-done:
+		True(t, ok)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the second attempt did not start on the missed tick")
+	}
+	Equal(t, 2500*time.Millisecond, fake.Since(start))
+}
+
+func TestEventuallyWithPollingMaxAttempts(t *testing.T) {
+	mockT := new(captureTestingT)
+	n := 0
+	False(t, EventuallyWithPolling(mockT, func() bool {
+		n++
+		return false
+	}, time.Hour, Polling{Tick: time.Millisecond, MaxAttempts: 3}))
+	Equal(t, 3, n)
+	Contains(t, mockT.msg, "Condition never satisfied after 3 attempt(s) in ")
+	Contains(t, mockT.msg, "(maximum attempts reached)")
+}
+
+func TestEventuallyWithPollingStillRunning(t *testing.T) {
+	mockT := new(captureTestingT)
+	release := make(chan struct{})
+	defer close(release)
+	False(t, EventuallyWithPolling(mockT, func() bool {
+		<-release
+		return true
+	}, 50*time.Millisecond, Polling{Tick: time.Millisecond, Immediate: true}))
+	Contains(t, mockT.msg, "Condition never satisfied after 1 attempt(s) in ")
+	Contains(t, mockT.msg, "Last attempt still running")
+}
+
+func TestEventuallyWithValue(t *testing.T) {
+	n := 0
+	True(t, EventuallyWithValue(t, func() (bool, interface{}) {
+		n++
+		return n == 2, n
+	}, time.Second, Polling{Tick: time.Millisecond}))
+
+	mockT := new(captureTestingT)
+	False(t, EventuallyWithValue(mockT, func() (bool, interface{}) {
+		return false, "pending"
+	}, time.Hour, Polling{Tick: time.Millisecond, MaxAttempts: 2}))
+	Contains(t, mockT.msg, `Last value: "pending"`)
+}
+
+func TestEventuallyContext(t *testing.T) {
+	n := 0
+	True(t, EventuallyContext(t, context.Background(), func(ctx context.Context) bool {
+		n++
+		return n == 2
+	}, time.Millisecond))
+
+	// The running condition observes the cancellation and is waited for.
+	mockT := new(captureTestingT)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	returned := false
+	False(t, EventuallyContext(mockT, ctx, func(ctx context.Context) bool {
+		<-ctx.Done()
+		returned = true
+		return false
+	}, time.Millisecond))
+	True(t, returned, "the running condition should have returned")
+	Contains(t, mockT.msg, "Condition never satisfied after 1 attempt(s) in ")
+	Contains(t, mockT.msg, "Context done: context deadline exceeded")
+	NotContains(t, mockT.msg, "still running")
+}
+
+func TestEventuallyWithTContext(t *testing.T) {
+	mockT := new(errorsCapturingT)
+	n := 0
+	True(t, EventuallyWithTContext(mockT, context.Background(), func(ctx context.Context, collect *CollectT) {
+		n++
+		Equal(collect, 2, n)
+	}, time.Millisecond))
+	Len(t, mockT.errors, 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	False(t, EventuallyWithTContext(mockT, ctx, func(ctx context.Context, collect *CollectT) {
+		Fail(collect, "condition fixed failure")
+	}, time.Millisecond))
+	Len(t, mockT.errors, 1, "a canceled context should stop before the first attempt")
+	Contains(t, mockT.errors[0].Error(), "Context done: context canceled")
+
+	mockT = new(errorsCapturingT)
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	False(t, EventuallyWithTContext(mockT, ctx, func(ctx context.Context, collect *CollectT) {
+		<-ctx.Done()
+		Fail(collect, "condition fixed failure")
+	}, time.Millisecond))
+	Len(t, mockT.errors, 2)
+	Contains(t, mockT.errors[0].Error(), "condition fixed failure")
+}
+
+func TestNeverContext(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	True(t, NeverContext(t, ctx, func(ctx context.Context) bool { return false }, time.Millisecond))
+
+	mockT := new(testing.T)
+	n := 0
+	False(t, NeverContext(mockT, context.Background(), func(ctx context.Context) bool {
+		n++
+		return n == 2
+	}, time.Millisecond))
+}
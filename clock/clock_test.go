@@ -0,0 +1,119 @@
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/clock"
+)
+
+func TestRealClock(t *testing.T) {
+	c := clock.New()
+
+	start := c.Now()
+	c.Sleep(time.Millisecond)
+	assert.GreaterOrEqual(t, int64(c.Since(start)), int64(time.Millisecond))
+
+	<-c.After(time.Millisecond)
+
+	timer := c.NewTimer(time.Hour)
+	assert.True(t, timer.Reset(time.Millisecond))
+	<-timer.C()
+	assert.False(t, timer.Stop())
+
+	ticker := c.NewTicker(time.Millisecond)
+	defer ticker.Stop()
+	<-ticker.C()
+	<-ticker.C()
+}
+
+func TestFakeTimer(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(start)
+
+	timer := fake.NewTimer(time.Second)
+	after := fake.After(2 * time.Second)
+	assert.Equal(t, 2, fake.Waiters())
+
+	fake.Advance(999 * time.Millisecond)
+	assertNoTick(t, timer.C())
+	assert.Equal(t, start.Add(999*time.Millisecond), fake.Now())
+
+	fake.Advance(time.Millisecond)
+	assert.Equal(t, start.Add(time.Second), <-timer.C())
+	assert.False(t, timer.Stop())
+	assert.Equal(t, 1, fake.Waiters())
+
+	assert.False(t, timer.Reset(time.Second))
+	assert.True(t, timer.Stop())
+	fake.Advance(time.Hour)
+	assertNoTick(t, timer.C())
+	assert.Equal(t, start.Add(2*time.Second), <-after)
+	assert.Equal(t, start.Add(time.Hour+time.Second), fake.Now())
+	assert.Equal(t, time.Hour+time.Second, fake.Since(start))
+	assert.Equal(t, 0, fake.Waiters())
+
+	fake.Set(start)
+	assert.Equal(t, start.Add(time.Hour+time.Second), fake.Now(), "time should not move backwards")
+
+	assert.Equal(t, fake.Now(), <-fake.After(0))
+}
+
+func TestFakeTicker(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(start)
+
+	ticker := fake.NewTicker(time.Second)
+	fake.Advance(time.Second)
+	assert.Equal(t, start.Add(time.Second), <-ticker.C())
+	fake.Advance(time.Second)
+	assert.Equal(t, start.Add(2*time.Second), <-ticker.C())
+
+	// Ticks are dropped when the previous one has not been received.
+	fake.Advance(3 * time.Second)
+	assert.Equal(t, start.Add(3*time.Second), <-ticker.C())
+	assertNoTick(t, ticker.C())
+
+	ticker.Reset(time.Minute)
+	fake.Advance(time.Second)
+	assertNoTick(t, ticker.C())
+	fake.Advance(time.Minute)
+	assert.Equal(t, start.Add(5*time.Second+time.Minute), <-ticker.C())
+
+	ticker.Stop()
+	fake.Advance(time.Hour)
+	assertNoTick(t, ticker.C())
+
+	assert.Panics(t, func() { fake.NewTicker(0) })
+}
+
+func TestFakeSleep(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(start)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fake.Sleep(time.Minute)
+	}()
+
+	fake.BlockUntil(1)
+	fake.Advance(59 * time.Second)
+	select {
+	case <-done:
+		t.Fatal("Sleep returned before the time was advanced")
+	default:
+	}
+	fake.Advance(time.Second)
+	<-done
+}
+
+func assertNoTick(t *testing.T, c <-chan time.Time) {
+	t.Helper()
+	select {
+	case tick := <-c:
+		t.Errorf("unexpected tick at %v", tick)
+	default:
+	}
+}
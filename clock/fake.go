@@ -0,0 +1,221 @@
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Fake is a Clock whose time only moves when Advance or Set is called.
+// Timers and tickers created by a Fake fire when the time is moved past
+// their deadline. A Fake is safe for concurrent use.
+//
+// Since the code under test usually waits in another goroutine, use
+// BlockUntil to wait for it to create its timers before advancing the
+// time:
+//
+//	fake := clock.NewFake(time.Now())
+//	go func() {
+//		fake.BlockUntil(1)
+//		fake.Advance(time.Minute)
+//	}()
+//	fake.Sleep(time.Minute) // returns without sleeping
+type Fake struct {
+	mu      sync.Mutex
+	changed *sync.Cond // broadcast when waiters are added
+	now     time.Time
+	waiters []*fakeWaiter
+}
+
+// fakeWaiter is a pending timer or ticker of a Fake.
+type fakeWaiter struct {
+	when   time.Time
+	period time.Duration // zero for timers
+	c      chan time.Time
+}
+
+// NewFake returns a Fake clock set to now.
+func NewFake(now time.Time) *Fake {
+	f := &Fake{now: now}
+	f.changed = sync.NewCond(&f.mu)
+	return f
+}
+
+// Now returns the current time of the fake clock.
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Since returns the fake time elapsed since t.
+func (f *Fake) Since(t time.Time) time.Duration {
+	return f.Now().Sub(t)
+}
+
+// Sleep blocks until the fake time has moved forward by d.
+func (f *Fake) Sleep(d time.Duration) {
+	<-f.After(d)
+}
+
+// After returns a channel receiving the fake time once it has moved
+// forward by d.
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	return f.NewTimer(d).C()
+}
+
+// NewTimer returns a Timer firing once the fake time has moved forward
+// by d.
+func (f *Fake) NewTimer(d time.Duration) Timer {
+	w := &fakeWaiter{c: make(chan time.Time, 1)}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.schedule(w, d)
+	return &fakeTimer{fake: f, w: w}
+}
+
+// NewTicker returns a Ticker firing every time the fake time moves
+// forward by d. It panics if d <= 0.
+func (f *Fake) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("clock: non-positive interval for NewTicker")
+	}
+	w := &fakeWaiter{period: d, c: make(chan time.Time, 1)}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.schedule(w, d)
+	return &fakeTicker{fake: f, w: w}
+}
+
+// Advance moves the fake time forward by d, firing the timers and tickers
+// whose deadline is reached, in order.
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.set(f.now.Add(d))
+}
+
+// Set moves the fake time to t, firing the timers and tickers whose
+// deadline is reached, in order. The time never moves backwards.
+func (f *Fake) Set(t time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.set(t)
+}
+
+// Waiters returns the number of timers and tickers waiting for the fake
+// time to move.
+func (f *Fake) Waiters() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.waiters)
+}
+
+// BlockUntil blocks until at least n timers and tickers are waiting for
+// the fake time to move.
+func (f *Fake) BlockUntil(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for len(f.waiters) < n {
+		f.changed.Wait()
+	}
+}
+
+// set moves the time to t. f.mu must be held.
+func (f *Fake) set(t time.Time) {
+	for len(f.waiters) > 0 && !f.waiters[0].when.After(t) {
+		w := f.waiters[0]
+		f.waiters = f.waiters[1:]
+		f.now = w.when
+		fire(w, f.now)
+		if w.period > 0 {
+			f.schedule(w, w.period)
+		}
+	}
+	if t.After(f.now) {
+		f.now = t
+	}
+}
+
+// schedule registers w to fire after d, or fires it now if d <= 0.
+// f.mu must be held.
+func (f *Fake) schedule(w *fakeWaiter, d time.Duration) {
+	if d <= 0 && w.period == 0 {
+		fire(w, f.now)
+		return
+	}
+	w.when = f.now.Add(d)
+	i := sort.Search(len(f.waiters), func(i int) bool {
+		return f.waiters[i].when.After(w.when)
+	})
+	f.waiters = append(f.waiters, nil)
+	copy(f.waiters[i+1:], f.waiters[i:])
+	f.waiters[i] = w
+	f.changed.Broadcast()
+}
+
+// unschedule removes w from the waiters and reports whether it was
+// waiting. f.mu must be held.
+func (f *Fake) unschedule(w *fakeWaiter) bool {
+	for i, waiter := range f.waiters {
+		if waiter == w {
+			f.waiters = append(f.waiters[:i], f.waiters[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// fire sends now on the channel of w, dropping the tick if the previous
+// one has not been received, like a time.Ticker.
+func fire(w *fakeWaiter, now time.Time) {
+	select {
+	case w.c <- now:
+	default:
+	}
+}
+
+type fakeTimer struct {
+	fake *Fake
+	w    *fakeWaiter
+}
+
+func (t *fakeTimer) C() <-chan time.Time { return t.w.c }
+
+func (t *fakeTimer) Stop() bool {
+	t.fake.mu.Lock()
+	defer t.fake.mu.Unlock()
+	return t.fake.unschedule(t.w)
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	t.fake.mu.Lock()
+	defer t.fake.mu.Unlock()
+	active := t.fake.unschedule(t.w)
+	t.fake.schedule(t.w, d)
+	return active
+}
+
+type fakeTicker struct {
+	fake *Fake
+	w    *fakeWaiter
+}
+
+func (t *fakeTicker) C() <-chan time.Time { return t.w.c }
+
+func (t *fakeTicker) Stop() {
+	t.fake.mu.Lock()
+	defer t.fake.mu.Unlock()
+	t.fake.unschedule(t.w)
+}
+
+func (t *fakeTicker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("clock: non-positive interval for Ticker.Reset")
+	}
+	t.fake.mu.Lock()
+	defer t.fake.mu.Unlock()
+	t.fake.unschedule(t.w)
+	t.w.period = d
+	t.fake.schedule(t.w, d)
+}
@@ -8,6 +8,10 @@
 //
 // The suite package provides a basic structure for using structs as testing suites, and methods on those structs as tests.  It includes setup/teardown functionality in the way of interfaces.
 //
+// The httpmock package provides an HTTP server answering the requests declared by a test, and failing it on any other request.
+//
+// The clock package provides a fake clock to test time-based code, including the polling assertions of the assert package, without sleeping.
+//
 // A [golangci-lint] compatible linter for testify is available called [testifylint].
 //
 // [golangci-lint]: https://golangci-lint.run/
@@ -1,2 +1,7 @@
-// Deprecated: Use [net/http/httptest] instead.
+// Package http provides ResponseRecorder, an http.ResponseWriter recording
+// the response of a handler for assertions, including streamed responses,
+// and Stream, which asserts on the Server-Sent Events or newline-delimited
+// JSON streamed by a running handler.
+//
+// The other types of the package are deprecated.
 package http
@@ -0,0 +1,306 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ResponseRecorder is an http.ResponseWriter recording the response of a
+// handler. Unlike httptest.ResponseRecorder, it records the chunks of the
+// body flushed by the handler, supports hijacking the connection and HTTP/2
+// server pushes, and records misuses of the http.ResponseWriter such as
+// superfluous WriteHeader calls.
+//
+// A ResponseRecorder is safe for concurrent use, so that a test can check
+// the chunks flushed by a handler running in another goroutine.
+type ResponseRecorder struct {
+	mu sync.Mutex
+	// changed is closed, and replaced, when a chunk is flushed.
+	changed chan struct{}
+
+	header        http.Header
+	writtenHeader http.Header
+	code          int
+	wroteHeader   bool
+	informational []int
+
+	body    bytes.Buffer
+	flushed int
+	chunks  [][]byte
+
+	pushes     []Push
+	hijacked   bool
+	clientConn net.Conn
+
+	violations []string
+}
+
+// Push is an HTTP/2 server push initiated by a handler.
+type Push struct {
+	Target  string
+	Options *http.PushOptions
+}
+
+var (
+	_ http.ResponseWriter = (*ResponseRecorder)(nil)
+	_ http.Flusher        = (*ResponseRecorder)(nil)
+	_ http.Hijacker       = (*ResponseRecorder)(nil)
+	_ http.Pusher         = (*ResponseRecorder)(nil)
+)
+
+// NewResponseRecorder returns a new ResponseRecorder.
+func NewResponseRecorder() *ResponseRecorder {
+	return &ResponseRecorder{
+		changed: make(chan struct{}),
+		header:  make(http.Header),
+	}
+}
+
+// Header returns the header map that will be sent by WriteHeader.
+func (r *ResponseRecorder) Header() http.Header {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.header
+}
+
+// WriteHeader records the status code and a snapshot of the header. Calls
+// after the status code was written, explicitly or by Write, are recorded
+// as violations, as net/http logs them as superfluous.
+func (r *ResponseRecorder) WriteHeader(code int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.writeHeader(code)
+}
+
+func (r *ResponseRecorder) writeHeader(code int) {
+	switch {
+	case r.hijacked:
+		r.violate("WriteHeader(%d) called after Hijack", code)
+		return
+	case code < 100 || code > 999:
+		r.violate("WriteHeader(%d) called with an invalid status code", code)
+		return
+	case r.wroteHeader:
+		r.violate("superfluous WriteHeader(%d) call, status %d was already written", code, r.code)
+		return
+	case code >= 100 && code <= 199 && code != http.StatusSwitchingProtocols:
+		// Informational responses precede the final one.
+		r.informational = append(r.informational, code)
+		return
+	}
+	r.code = code
+	r.wroteHeader = true
+	r.writtenHeader = r.header.Clone()
+}
+
+// Write records data as part of the body, writing the status code 200 OK
+// if WriteHeader was not called.
+func (r *ResponseRecorder) Write(data []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.hijacked {
+		r.violate("Write called after Hijack")
+		return 0, http.ErrHijacked
+	}
+	if !r.wroteHeader {
+		r.writeHeader(http.StatusOK)
+	}
+	return r.body.Write(data)
+}
+
+// Flush records the data written since the previous flush as a chunk,
+// writing the status code 200 OK if WriteHeader was not called.
+func (r *ResponseRecorder) Flush() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.hijacked {
+		r.violate("Flush called after Hijack")
+		return
+	}
+	if !r.wroteHeader {
+		r.writeHeader(http.StatusOK)
+	}
+	if r.body.Len() == r.flushed {
+		return
+	}
+	r.chunks = append(r.chunks, append([]byte(nil), r.body.Bytes()[r.flushed:]...))
+	r.flushed = r.body.Len()
+	close(r.changed)
+	r.changed = make(chan struct{})
+}
+
+// Hijack returns the server end of an in-memory connection, whose client
+// end is returned by ClientConn.
+func (r *ResponseRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.hijacked {
+		r.violate("Hijack called twice")
+		return nil, nil, errors.New("connection already hijacked")
+	}
+	r.hijacked = true
+	server, client := net.Pipe()
+	r.clientConn = client
+	return server, bufio.NewReadWriter(bufio.NewReader(server), bufio.NewWriter(server)), nil
+}
+
+// ClientConn returns the client end of the connection hijacked by the
+// handler, or nil if it was not hijacked.
+func (r *ResponseRecorder) ClientConn() net.Conn {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.clientConn
+}
+
+// Push records an HTTP/2 server push.
+func (r *ResponseRecorder) Push(target string, opts *http.PushOptions) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.hijacked {
+		r.violate("Push called after Hijack")
+		return http.ErrHijacked
+	}
+	r.pushes = append(r.pushes, Push{Target: target, Options: opts})
+	return nil
+}
+
+func (r *ResponseRecorder) violate(format string, args ...interface{}) {
+	r.violations = append(r.violations, fmt.Sprintf(format, args...))
+}
+
+// Code returns the status code written by the handler, 200 OK if it did
+// not write any.
+func (r *ResponseRecorder) Code() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.wroteHeader {
+		return http.StatusOK
+	}
+	return r.code
+}
+
+// WrittenHeader returns the header as it was when the status code was
+// written, or nil if it was not written.
+func (r *ResponseRecorder) WrittenHeader() http.Header {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.writtenHeader.Clone()
+}
+
+// Informational returns the informational (1xx) status codes written by
+// the handler before the final status code.
+func (r *ResponseRecorder) Informational() []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]int(nil), r.informational...)
+}
+
+// Body returns the whole body written by the handler.
+func (r *ResponseRecorder) Body() []byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]byte(nil), r.body.Bytes()...)
+}
+
+// Chunks returns the parts of the body flushed by the handler, one for
+// each call to Flush following a write. Data written but not flushed is
+// not included.
+func (r *ResponseRecorder) Chunks() [][]byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	chunks := make([][]byte, len(r.chunks))
+	for i, chunk := range r.chunks {
+		chunks[i] = append([]byte(nil), chunk...)
+	}
+	return chunks
+}
+
+// WaitForChunks waits up to timeout for the handler to flush at least n
+// chunks, and reports whether it did.
+func (r *ResponseRecorder) WaitForChunks(n int, timeout time.Duration) bool {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		r.mu.Lock()
+		count, changed := len(r.chunks), r.changed
+		r.mu.Unlock()
+		if count >= n {
+			return true
+		}
+		select {
+		case <-changed:
+		case <-timer.C:
+			return false
+		}
+	}
+}
+
+// Pushes returns the HTTP/2 server pushes initiated by the handler.
+func (r *ResponseRecorder) Pushes() []Push {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Push(nil), r.pushes...)
+}
+
+// Violations returns the misuses of the http.ResponseWriter by the
+// handler, such as superfluous WriteHeader calls or writes after Hijack.
+func (r *ResponseRecorder) Violations() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.violations...)
+}
+
+// AssertNoViolations asserts that the handler did not misuse the
+// http.ResponseWriter.
+func (r *ResponseRecorder) AssertNoViolations(t assert.TestingT, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if violations := r.Violations(); len(violations) > 0 {
+		return assert.Fail(t, fmt.Sprintf("Handler misused the http.ResponseWriter:\n%s", bulletList(violations)), msgAndArgs...)
+	}
+	return true
+}
+
+// AssertChunks asserts that the handler flushed exactly the expected
+// chunks, in order.
+//
+//	recorder.AssertChunks(t, "data: 1\n\n", "data: 2\n\n")
+func (r *ResponseRecorder) AssertChunks(t assert.TestingT, expected ...string) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	chunks := r.Chunks()
+	actual := make([]string, len(chunks))
+	for i, chunk := range chunks {
+		actual[i] = string(chunk)
+	}
+	if expected == nil {
+		expected = []string{}
+	}
+	return assert.Equal(t, expected, actual, "Flushed chunks differ")
+}
+
+type tHelper = interface {
+	Helper()
+}
+
+func bulletList(items []string) string {
+	var b bytes.Buffer
+	for i, item := range items {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString("\t- ")
+		b.WriteString(item)
+	}
+	return b.String()
+}
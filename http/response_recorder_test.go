@@ -0,0 +1,142 @@
+package http
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type captureT struct {
+	msg string
+}
+
+func (t *captureT) Errorf(format string, args ...interface{}) {
+	t.msg = fmt.Sprintf(format, args...)
+}
+
+func TestResponseRecorderWrite(t *testing.T) {
+	rec := NewResponseRecorder()
+	rec.Header().Set("Content-Type", "text/plain")
+	_, _ = rec.Write([]byte("hello"))
+	rec.Header().Set("X-Late", "ignored")
+
+	assert.Equal(t, http.StatusOK, rec.Code())
+	assert.Equal(t, "hello", string(rec.Body()))
+	assert.Equal(t, "text/plain", rec.WrittenHeader().Get("Content-Type"))
+	assert.Empty(t, rec.WrittenHeader().Get("X-Late"))
+	assert.Empty(t, rec.Chunks())
+	rec.AssertNoViolations(t)
+}
+
+func TestResponseRecorderViolations(t *testing.T) {
+	rec := NewResponseRecorder()
+	rec.WriteHeader(http.StatusEarlyHints)
+	rec.WriteHeader(http.StatusCreated)
+	rec.WriteHeader(http.StatusOK)
+
+	assert.Equal(t, http.StatusCreated, rec.Code())
+	assert.Equal(t, []int{http.StatusEarlyHints}, rec.Informational())
+	assert.Equal(t, []string{"superfluous WriteHeader(200) call, status 201 was already written"}, rec.Violations())
+
+	mockT := new(captureT)
+	assert.False(t, rec.AssertNoViolations(mockT))
+	assert.Contains(t, mockT.msg, "superfluous WriteHeader(200)")
+}
+
+func TestResponseRecorderFlush(t *testing.T) {
+	rec := NewResponseRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for i := 1; i <= 3; i++ {
+			fmt.Fprintf(w, "data: %d\n\n", i)
+			w.(http.Flusher).Flush()
+		}
+		// Not flushed.
+		fmt.Fprint(w, "tail")
+	})
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/events", nil))
+
+	assert.True(t, rec.AssertChunks(t, "data: 1\n\n", "data: 2\n\n", "data: 3\n\n"))
+	assert.Equal(t, "data: 1\n\ndata: 2\n\ndata: 3\n\ntail", string(rec.Body()))
+
+	mockT := new(captureT)
+	assert.False(t, rec.AssertChunks(mockT, "data: 1\n\n"))
+	assert.Contains(t, mockT.msg, "Flushed chunks differ")
+}
+
+func TestResponseRecorderWaitForChunks(t *testing.T) {
+	rec := NewResponseRecorder()
+	release := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fmt.Fprint(rec, "first")
+		rec.Flush()
+		<-release
+		fmt.Fprint(rec, "second")
+		rec.Flush()
+	}()
+
+	assert.True(t, rec.WaitForChunks(1, time.Second))
+	assert.False(t, rec.WaitForChunks(2, 10*time.Millisecond))
+	close(release)
+	assert.True(t, rec.WaitForChunks(2, time.Second))
+	<-done
+	rec.AssertChunks(t, "first", "second")
+}
+
+func TestResponseRecorderHijack(t *testing.T) {
+	rec := NewResponseRecorder()
+	done := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, rw, err := w.(http.Hijacker).Hijack()
+		if !assert.NoError(t, err) {
+			return
+		}
+		go func() {
+			defer close(done)
+			defer conn.Close()
+			line, _ := rw.ReadString('\n')
+			_, _ = rw.WriteString("echo: " + line)
+			_ = rw.Flush()
+		}()
+
+		_, err = w.Write([]byte("too late"))
+		assert.Equal(t, http.ErrHijacked, err)
+	})
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/ws", nil))
+
+	client := rec.ClientConn()
+	if !assert.NotNil(t, client) {
+		return
+	}
+	_, err := io.WriteString(client, "ping\n")
+	assert.NoError(t, err)
+	reply, err := bufio.NewReader(client).ReadString('\n')
+	assert.NoError(t, err)
+	assert.Equal(t, "echo: ping\n", reply)
+	<-done
+
+	assert.Equal(t, []string{"Write called after Hijack"}, rec.Violations())
+}
+
+func TestResponseRecorderPush(t *testing.T) {
+	rec := NewResponseRecorder()
+	opts := &http.PushOptions{Method: "GET"}
+	assert.NoError(t, rec.Push("/style.css", opts))
+	assert.Equal(t, []Push{{Target: "/style.css", Options: opts}}, rec.Pushes())
+}
+
+func TestTestResponseWriterWrite(t *testing.T) {
+	w := new(TestResponseWriter)
+	n, err := w.Write([]byte("hello"))
+	assert.NoError(t, err)
+	assert.Equal(t, 5, n)
+	assert.True(t, strings.HasPrefix(w.Output, "hello"))
+}
@@ -0,0 +1,323 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Stream runs a handler in the background against a ResponseRecorder, so
+// that a test can assert on what the handler streams while it is running:
+// Server-Sent Events or newline-delimited JSON values.
+//
+// Only the data flushed by the handler is read until it returns, as a
+// client would see it. Each read waits for the next complete event or line
+// for at most the given timeout.
+//
+//	stream := http.NewStream(handler, httptest.NewRequest("GET", "/events", nil))
+//	defer stream.Close()
+//	stream.AssertEvents(t, time.Second, http.Event{Name: "ping", Data: "1"})
+type Stream struct {
+	*ResponseRecorder
+
+	cancel   context.CancelFunc
+	done     chan struct{}
+	panicked interface{}
+	// pos is the offset in the body of the data not read yet.
+	pos int
+}
+
+// Event is a Server-Sent Event. ID is the id field of the event itself,
+// empty if the event did not set one.
+type Event struct {
+	Name string
+	ID   string
+	Data string
+}
+
+var errStreamEnded = errors.New("stream ended")
+
+// NewStream starts serving req with handler in a new goroutine. The
+// context of the request is canceled by Close.
+func NewStream(handler http.Handler, req *http.Request) *Stream {
+	ctx, cancel := context.WithCancel(req.Context())
+	s := &Stream{
+		ResponseRecorder: NewResponseRecorder(),
+		cancel:           cancel,
+		done:             make(chan struct{}),
+	}
+	go func() {
+		defer close(s.done)
+		defer func() {
+			if r := recover(); r != nil {
+				s.panicked = r
+			}
+		}()
+		handler.ServeHTTP(s.ResponseRecorder, req.WithContext(ctx))
+	}()
+	return s
+}
+
+// Close cancels the context of the request and waits for the handler to
+// return.
+func (s *Stream) Close() {
+	s.cancel()
+	<-s.done
+}
+
+// Done returns a channel closed when the handler returns.
+func (s *Stream) Done() <-chan struct{} {
+	return s.done
+}
+
+// available returns the data readable by a client, whether the handler
+// returned, and a channel closed when more data may be available.
+func (s *Stream) available() (data []byte, ended bool, changed <-chan struct{}) {
+	select {
+	case <-s.done:
+		return s.Body(), true, nil
+	default:
+	}
+	r := s.ResponseRecorder
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.body.Bytes()[:r.flushed:r.flushed], false, r.changed
+}
+
+// next waits up to timeout for split to find a token in the unread data.
+// split returns the length of the data consumed and whether it found a
+// token.
+func (s *Stream) next(timeout time.Duration, split func(data []byte, atEOF bool) (advance int, token []byte, ok bool)) ([]byte, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		data, ended, changed := s.available()
+		advance, token, ok := split(data[s.pos:], ended)
+		s.pos += advance
+		if ok {
+			return token, nil
+		}
+		if ended {
+			return nil, errStreamEnded
+		}
+		select {
+		case <-changed:
+		case <-s.done:
+		case <-timer.C:
+			return nil, context.DeadlineExceeded
+		}
+	}
+}
+
+// failNext reports that reading the next element of the stream failed.
+func (s *Stream) failNext(t assert.TestingT, what string, timeout time.Duration, err error, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	var msg string
+	if err == errStreamEnded {
+		msg = fmt.Sprintf("Stream ended before the next %s", what)
+		if s.panicked != nil {
+			msg += fmt.Sprintf("\nHandler panicked: %v", s.panicked)
+		}
+	} else {
+		msg = fmt.Sprintf("No %s received within %s", what, timeout)
+	}
+	data, _, _ := s.available()
+	return assert.Fail(t, fmt.Sprintf("%s\nUnread data: %q", msg, data[s.pos:]), msgAndArgs...)
+}
+
+// NextEvent waits up to timeout for the next Server-Sent Event and returns
+// it. It fails if the handler returns before sending one. Comments and
+// events without data are skipped.
+func (s *Stream) NextEvent(t assert.TestingT, timeout time.Duration, msgAndArgs ...interface{}) (Event, bool) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		block, err := s.next(time.Until(deadline), splitEvent)
+		if err != nil {
+			return Event{}, s.failNext(t, "SSE event", timeout, err, msgAndArgs...)
+		}
+		if event, ok := parseEvent(block); ok {
+			return event, true
+		}
+	}
+}
+
+// AssertEvents asserts that the next Server-Sent Events are the expected
+// ones, waiting up to timeout for each of them.
+//
+//	stream.AssertEvents(t, time.Second, http.Event{Name: "update", ID: "1", Data: `{"n":1}`})
+func (s *Stream) AssertEvents(t assert.TestingT, timeout time.Duration, expected ...Event) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	for i, want := range expected {
+		got, ok := s.NextEvent(t, timeout, "Event %d", i)
+		if !ok {
+			return false
+		}
+		if !assert.Equal(t, want, got, "Event %d", i) {
+			return false
+		}
+	}
+	return true
+}
+
+// NextLine waits up to timeout for the next non-empty line of a
+// newline-delimited JSON stream and returns it. It fails if the line is
+// not valid JSON or if the handler returns before sending one.
+func (s *Stream) NextLine(t assert.TestingT, timeout time.Duration, msgAndArgs ...interface{}) (string, bool) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		line, err := s.next(time.Until(deadline), splitLine)
+		if err != nil {
+			return "", s.failNext(t, "JSON line", timeout, err, msgAndArgs...)
+		}
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		if !json.Valid(line) {
+			return "", assert.Fail(t, fmt.Sprintf("Received line is not valid JSON: %q", line), msgAndArgs...)
+		}
+		return string(line), true
+	}
+}
+
+// AssertJSONLines asserts that the next lines of a newline-delimited JSON
+// stream are equivalent to the expected JSON values, waiting up to timeout
+// for each of them.
+//
+//	stream.AssertJSONLines(t, time.Second, `{"n": 1}`, `{"n": 2}`)
+func (s *Stream) AssertJSONLines(t assert.TestingT, timeout time.Duration, expected ...string) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	for i, want := range expected {
+		got, ok := s.NextLine(t, timeout, "Line %d", i)
+		if !ok {
+			return false
+		}
+		if !assert.JSONEq(t, want, got, "Line %d", i) {
+			return false
+		}
+	}
+	return true
+}
+
+// AssertEnd asserts that the handler returns within timeout, without
+// sending more data than what was already read.
+func (s *Stream) AssertEnd(t assert.TestingT, timeout time.Duration, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	select {
+	case <-s.done:
+	case <-time.After(timeout):
+		return assert.Fail(t, fmt.Sprintf("Handler did not return within %s", timeout), msgAndArgs...)
+	}
+	if s.panicked != nil {
+		return assert.Fail(t, fmt.Sprintf("Handler panicked: %v", s.panicked), msgAndArgs...)
+	}
+	if rest := bytes.TrimSpace(s.Body()[s.pos:]); len(rest) > 0 {
+		return assert.Fail(t, fmt.Sprintf("Stream ended with unread data: %q", rest), msgAndArgs...)
+	}
+	return true
+}
+
+// lineEnd returns the length of the first line of data and of its line
+// terminator, which may be "\r\n", "\n" or "\r". It returns -1 if data
+// holds no complete line.
+func lineEnd(data []byte, atEOF bool) (n, term int) {
+	i := bytes.IndexAny(data, "\r\n")
+	switch {
+	case i < 0:
+		return -1, 0
+	case data[i] == '\n':
+		return i, 1
+	case i+1 < len(data):
+		if data[i+1] == '\n' {
+			return i, 2
+		}
+		return i, 1
+	case atEOF:
+		return i, 1
+	}
+	// A "\r" at the end of the data may be followed by "\n".
+	return -1, 0
+}
+
+// splitEvent splits the lines of an SSE stream up to the blank line ending
+// an event.
+func splitEvent(data []byte, atEOF bool) (advance int, token []byte, ok bool) {
+	for pos := 0; ; {
+		n, term := lineEnd(data[pos:], atEOF)
+		if n < 0 {
+			return 0, nil, false
+		}
+		if n == 0 {
+			return pos + term, data[:pos], true
+		}
+		pos += n + term
+	}
+}
+
+// splitLine splits the first line of data.
+func splitLine(data []byte, atEOF bool) (advance int, token []byte, ok bool) {
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		return i + 1, data[:i], true
+	}
+	if atEOF && len(data) > 0 {
+		return len(data), data, true
+	}
+	return 0, nil, false
+}
+
+// parseEvent parses the lines of an SSE event, reporting false if it has
+// no data. Fields other than event, id and data, such as retry, are
+// ignored.
+func parseEvent(block []byte) (Event, bool) {
+	var event Event
+	var data []string
+	hasData := false
+	for len(block) > 0 {
+		n, term := lineEnd(block, true)
+		if n < 0 {
+			n = len(block)
+		}
+		line := string(block[:n])
+		block = block[n+term:]
+
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+		field, value := line, ""
+		if i := strings.IndexByte(line, ':'); i >= 0 {
+			field, value = line[:i], strings.TrimPrefix(line[i+1:], " ")
+		}
+		switch field {
+		case "event":
+			event.Name = value
+		case "id":
+			event.ID = value
+		case "data":
+			data = append(data, value)
+			hasData = true
+		}
+	}
+	event.Data = strings.Join(data, "\n")
+	return event, hasData
+}
@@ -0,0 +1,100 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamEvents(t *testing.T) {
+	release := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, ": comment\r\n\r\nevent: greeting\r\nid: 1\r\ndata: hello\r\ndata: world\r\n\r\n")
+		w.(http.Flusher).Flush()
+		<-release
+		fmt.Fprint(w, "retry: 10\n\ndata:no space\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	})
+	stream := NewStream(handler, httptest.NewRequest("GET", "/events", nil))
+	defer stream.Close()
+
+	assert.True(t, stream.AssertEvents(t, time.Second, Event{Name: "greeting", ID: "1", Data: "hello\nworld"}))
+
+	mockT := new(captureT)
+	_, ok := stream.NextEvent(mockT, 10*time.Millisecond)
+	assert.False(t, ok)
+	assert.Contains(t, mockT.msg, "No SSE event received within 10ms")
+
+	close(release)
+	assert.True(t, stream.AssertEvents(t, time.Second, Event{Data: "no space"}))
+
+	mockT = new(captureT)
+	assert.False(t, stream.AssertEnd(mockT, 10*time.Millisecond))
+	assert.Contains(t, mockT.msg, "Handler did not return within 10ms")
+
+	stream.Close()
+	assert.True(t, stream.AssertEnd(t, time.Second))
+}
+
+func TestStreamEventsMismatch(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "event: a\ndata: 1\n\n")
+	})
+	stream := NewStream(handler, httptest.NewRequest("GET", "/events", nil))
+	defer stream.Close()
+
+	mockT := new(captureT)
+	assert.False(t, stream.AssertEvents(mockT, time.Second, Event{Name: "b", Data: "1"}))
+	assert.Contains(t, mockT.msg, "Event 0")
+
+	mockT = new(captureT)
+	assert.False(t, stream.AssertEvents(mockT, time.Second, Event{Data: "2"}))
+	assert.Contains(t, mockT.msg, "Stream ended before the next SSE event")
+}
+
+func TestStreamJSONLines(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for i := 1; i <= 2; i++ {
+			fmt.Fprintf(w, "{\"n\": %d}\n\n", i)
+			w.(http.Flusher).Flush()
+		}
+		// Not flushed, read once the handler returns.
+		fmt.Fprint(w, `{"n": 3}`)
+	})
+	stream := NewStream(handler, httptest.NewRequest("GET", "/stream", nil))
+	defer stream.Close()
+
+	assert.True(t, stream.AssertJSONLines(t, time.Second, `{"n":1}`, `{"n":2}`, `{"n":3}`))
+	assert.True(t, stream.AssertEnd(t, time.Second))
+}
+
+func TestStreamJSONLinesInvalid(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "{\"n\": 1}\nnot json\n")
+	})
+	stream := NewStream(handler, httptest.NewRequest("GET", "/stream", nil))
+	defer stream.Close()
+
+	mockT := new(captureT)
+	assert.False(t, stream.AssertJSONLines(mockT, time.Second, `{"n": 1}`, `{"n": 2}`))
+	assert.Contains(t, mockT.msg, `Received line is not valid JSON: "not json"`)
+}
+
+func TestStreamPanic(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})
+	stream := NewStream(handler, httptest.NewRequest("GET", "/stream", nil))
+	defer stream.Close()
+
+	mockT := new(captureT)
+	_, ok := stream.NextEvent(mockT, time.Second)
+	assert.False(t, ok)
+	assert.Contains(t, mockT.msg, "Handler panicked: boom")
+}
@@ -4,7 +4,7 @@ import (
 	"net/http"
 )
 
-// Deprecated: Use [net/http/httptest] instead.
+// Deprecated: Use [ResponseRecorder] instead.
 type TestResponseWriter struct {
 
 	// StatusCode is the last int written by the call to WriteHeader(int)
@@ -17,7 +17,7 @@ type TestResponseWriter struct {
 	header http.Header
 }
 
-// Deprecated: Use [net/http/httptest] instead.
+// Deprecated: Use [ResponseRecorder] instead.
 func (rw *TestResponseWriter) Header() http.Header {
 
 	if rw.header == nil {
@@ -27,7 +27,7 @@ func (rw *TestResponseWriter) Header() http.Header {
 	return rw.header
 }
 
-// Deprecated: Use [net/http/httptest] instead.
+// Deprecated: Use [ResponseRecorder] instead.
 func (rw *TestResponseWriter) Write(bytes []byte) (int, error) {
 
 	// assume 200 success if no header has been set
@@ -39,11 +39,11 @@ func (rw *TestResponseWriter) Write(bytes []byte) (int, error) {
 	rw.Output += string(bytes)
 
 	// return normal values
-	return 0, nil
+	return len(bytes), nil
 
 }
 
-// Deprecated: Use [net/http/httptest] instead.
+// Deprecated: Use [ResponseRecorder] instead.
 func (rw *TestResponseWriter) WriteHeader(i int) {
 	rw.StatusCode = i
 }
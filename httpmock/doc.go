@@ -0,0 +1,32 @@
+// Package httpmock provides an HTTP server for tests, answering the
+// requests declared by the test and failing it on any other request.
+//
+// Routes are declared with Server.On, narrowed down with conditions on the
+// request and given a response:
+//
+//	func TestClient(t *testing.T) {
+//	  server := httpmock.NewServer(t)
+//	  defer server.Close()
+//
+//	  server.On("POST", "/users").
+//	    WithHeader("Content-Type", "application/json").
+//	    WithJSONBody(`{"name": "gopher"}`).
+//	    RespondJSON(http.StatusCreated, map[string]interface{}{"id": 42}).
+//	    Once()
+//
+//	  client := NewClient(server.URL)
+//	  ...
+//
+//	  server.AssertExpectations(t)
+//	}
+//
+// Routes are expectations of a mock.Mock: like mock calls, they can be
+// repeated a limited number of times with Once, Twice or Times, or be made
+// optional with Maybe. A request matching no route fails the test with the
+// differences between the request and the closest route, and is answered
+// with a 404 Not Found status.
+//
+// The package also provides Recorder, an http.RoundTripper recording the
+// HTTP interactions of a client with a real server in a cassette file, and
+// replaying them in later runs of the test.
+package httpmock
@@ -0,0 +1,353 @@
+package httpmock
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+var record = flag.Bool("httpmock.record", false, "record the HTTP interactions of httpmock.Recorder in their cassettes instead of replaying them")
+
+// Mode is whether a Recorder records or replays HTTP interactions.
+type Mode int
+
+const (
+	// Replay answers requests with the responses recorded in the cassette,
+	// without sending them.
+	Replay Mode = iota
+	// Record sends requests and saves them, with their responses, in the
+	// cassette.
+	Record
+)
+
+// Match is a set of properties of requests compared to find the recorded
+// interaction answering a request.
+type Match int
+
+const (
+	// MatchMethod compares the methods of requests.
+	MatchMethod Match = 1 << iota
+	// MatchPath compares the paths of the URLs of requests.
+	MatchPath
+	// MatchQuery compares the query parameters of requests, in any order.
+	MatchQuery
+	// MatchBody compares the bodies of requests.
+	MatchBody
+
+	// DefaultMatch matches the method, the path and the query of requests.
+	DefaultMatch = MatchMethod | MatchPath | MatchQuery
+)
+
+// Redacted replaces the values of redacted headers in cassettes.
+const Redacted = "REDACTED"
+
+// DefaultRedactedHeaders are the headers redacted from cassettes unless
+// WithRedactedHeaders is used.
+var DefaultRedactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// Recorder is an http.RoundTripper recording HTTP interactions in a
+// cassette file, and replaying them from it, for tests needing realistic
+// responses without network access.
+//
+// Record the interactions once, against a real server, by running the
+// tests with the -httpmock.record flag, and commit the cassettes written
+// under testdata:
+//
+//	func TestClient(t *testing.T) {
+//	  recorder := httpmock.NewRecorder(t, "client")
+//	  client := &http.Client{Transport: recorder}
+//	  ...
+//	}
+type Recorder struct {
+	t        TestingT
+	path     string
+	mode     Mode
+	inner    http.RoundTripper
+	match    Match
+	headers  []string
+	redacted []string
+
+	mu           sync.Mutex
+	interactions []*interaction
+	used         []bool
+	saved        bool
+}
+
+// RecorderOption configures a Recorder.
+type RecorderOption func(*Recorder)
+
+// WithMode sets the mode of the recorder, overriding the -httpmock.record
+// flag.
+func WithMode(mode Mode) RecorderOption {
+	return func(r *Recorder) { r.mode = mode }
+}
+
+// WithTransport sets the transport sending requests in Record mode,
+// http.DefaultTransport by default.
+func WithTransport(transport http.RoundTripper) RecorderOption {
+	return func(r *Recorder) { r.inner = transport }
+}
+
+// WithMatch sets the properties of requests compared to find the recorded
+// interaction answering a request, DefaultMatch by default.
+func WithMatch(match Match) RecorderOption {
+	return func(r *Recorder) { r.match = match }
+}
+
+// WithMatchHeaders adds headers compared to find the recorded interaction
+// answering a request. Redacted headers only match on their presence.
+func WithMatchHeaders(names ...string) RecorderOption {
+	return func(r *Recorder) { r.headers = append(r.headers, names...) }
+}
+
+// WithRedactedHeaders sets the headers of requests and responses whose
+// values are replaced with Redacted in the cassette, instead of
+// DefaultRedactedHeaders.
+func WithRedactedHeaders(names ...string) RecorderOption {
+	return func(r *Recorder) { r.redacted = names }
+}
+
+// interaction is a request and its response, as saved in a cassette.
+type interaction struct {
+	Request struct {
+		Method string      `json:"method"`
+		URL    string      `json:"url"`
+		Header http.Header `json:"header,omitempty"`
+		Body   string      `json:"body,omitempty"`
+	} `json:"request"`
+	Response struct {
+		StatusCode int         `json:"status_code"`
+		Header     http.Header `json:"header,omitempty"`
+		Body       string      `json:"body,omitempty"`
+	} `json:"response"`
+}
+
+// NewRecorder returns a Recorder using the cassette testdata/<name>.json.
+// In Replay mode, it fails t if the cassette cannot be read. In Record
+// mode, the cassette is written by Save, which is called when the test
+// ends if t has a Cleanup method, as *testing.T does.
+func NewRecorder(t TestingT, name string, options ...RecorderOption) *Recorder {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	r := &Recorder{
+		t:        t,
+		path:     filepath.Join("testdata", name+".json"),
+		inner:    http.DefaultTransport,
+		match:    DefaultMatch,
+		redacted: DefaultRedactedHeaders,
+	}
+	if *record {
+		r.mode = Record
+	}
+	for _, option := range options {
+		option(r)
+	}
+
+	switch r.mode {
+	case Replay:
+		if err := r.load(); err != nil {
+			t.Errorf("httpmock: cannot load cassette: %s", err)
+		}
+	case Record:
+		if c, ok := t.(interface{ Cleanup(func()) }); ok {
+			c.Cleanup(func() {
+				if err := r.Save(); err != nil {
+					t.Errorf("httpmock: %s", err)
+				}
+			})
+		}
+	}
+	return r
+}
+
+// RoundTrip records or replays the interaction of req.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if r.mode == Record {
+		return r.record(req, body)
+	}
+	return r.replay(req, body)
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	out := req.Clone(req.Context())
+	out.Body = io.NopCloser(bytes.NewReader(body))
+	resp, err := r.inner.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	i := new(interaction)
+	i.Request.Method = req.Method
+	i.Request.URL = req.URL.String()
+	i.Request.Header = r.redact(req.Header)
+	i.Request.Body = string(body)
+	i.Response.StatusCode = resp.StatusCode
+	i.Response.Header = r.redact(resp.Header)
+	i.Response.Body = string(respBody)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.interactions = append(r.interactions, i)
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Prefer the first interaction not replayed yet, to replay successive
+	// responses to the same request in order.
+	found := -1
+	for i, recorded := range r.interactions {
+		if r.matches(recorded, req, body) {
+			if !r.used[i] {
+				found = i
+				break
+			}
+			if found < 0 {
+				found = i
+			}
+		}
+	}
+	if found < 0 {
+		err := fmt.Errorf("httpmock: no interaction recorded in %s matches %s %s", r.path, req.Method, req.URL)
+		r.t.Errorf("%s", err)
+		return nil, err
+	}
+	r.used[found] = true
+
+	recorded := r.interactions[found]
+	header := recorded.Response.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.Response.StatusCode, http.StatusText(recorded.Response.StatusCode)),
+		StatusCode:    recorded.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(recorded.Response.Body)),
+		ContentLength: int64(len(recorded.Response.Body)),
+		Request:       req,
+	}, nil
+}
+
+// matches reports whether recorded answers req.
+func (r *Recorder) matches(recorded *interaction, req *http.Request, body []byte) bool {
+	u, err := url.Parse(recorded.Request.URL)
+	if err != nil {
+		return false
+	}
+	if r.match&MatchMethod != 0 && recorded.Request.Method != req.Method {
+		return false
+	}
+	if r.match&MatchPath != 0 && u.Path != req.URL.Path {
+		return false
+	}
+	if r.match&MatchQuery != 0 && !reflect.DeepEqual(u.Query(), req.URL.Query()) {
+		return false
+	}
+	if r.match&MatchBody != 0 && recorded.Request.Body != string(body) {
+		return false
+	}
+	header := r.redact(req.Header)
+	for _, name := range r.headers {
+		if !reflect.DeepEqual(recorded.Request.Header.Values(name), header.Values(name)) {
+			return false
+		}
+	}
+	return true
+}
+
+// redact returns a copy of header with the values of redacted headers
+// replaced with Redacted.
+func (r *Recorder) redact(header http.Header) http.Header {
+	header = header.Clone()
+	for _, name := range r.redacted {
+		values := header.Values(name)
+		if len(values) == 0 {
+			continue
+		}
+		redacted := make([]string, len(values))
+		for i := range redacted {
+			redacted[i] = Redacted
+		}
+		header[http.CanonicalHeaderKey(name)] = redacted
+	}
+	return header
+}
+
+func (r *Recorder) load() error {
+	data, err := os.ReadFile(r.path)
+	if err != nil {
+		return err
+	}
+	// Unmarshal fills part of the slice when it fails on a type error:
+	// only keep the interactions of a valid cassette.
+	var interactions []*interaction
+	if err := json.Unmarshal(data, &interactions); err != nil {
+		return fmt.Errorf("%s: %s", r.path, err)
+	}
+	r.interactions = interactions
+	r.used = make([]bool, len(interactions))
+	return nil
+}
+
+// Save writes the recorded interactions to the cassette. It does nothing
+// in Replay mode, or if the cassette was already saved.
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.mode != Record || r.saved {
+		return nil
+	}
+	r.saved = true
+
+	interactions := r.interactions
+	if interactions == nil {
+		interactions = []*interaction{}
+	}
+	data, err := json.MarshalIndent(interactions, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(r.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("cannot save cassette: %w", err)
+	}
+	return nil
+}
+
+var _ http.RoundTripper = (*Recorder)(nil)
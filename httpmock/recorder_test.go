@@ -0,0 +1,138 @@
+package httpmock
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// inTempDir runs f in a new temporary directory, where cassettes are
+// written to testdata.
+func inTempDir(t *testing.T, f func()) {
+	t.Helper()
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(t.TempDir()))
+	defer func() { require.NoError(t, os.Chdir(wd)) }()
+	f()
+}
+
+func send(t *testing.T, client *http.Client, method, url, body string, header http.Header) (*http.Response, string, error) {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	require.NoError(t, err)
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, string(b), nil
+}
+
+func TestRecorder(t *testing.T) {
+	inTempDir(t, func() {
+		hits := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hits++
+			body, _ := io.ReadAll(r.Body)
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "secret"})
+			w.Header().Set("X-Hits", strings.Repeat("x", hits))
+			_, _ = io.WriteString(w, r.Method+" "+r.URL.RequestURI()+" "+string(body))
+		}))
+		defer server.Close()
+
+		auth := http.Header{"Authorization": {"Bearer token"}, "X-Version": {"1"}}
+
+		recorder := NewRecorder(t, "recorder", WithMode(Record))
+		client := &http.Client{Transport: recorder}
+		_, body, err := send(t, client, "GET", server.URL+"/users?name=gopher", "", auth)
+		require.NoError(t, err)
+		assert.Equal(t, "GET /users?name=gopher ", body)
+		_, body, err = send(t, client, "POST", server.URL+"/users", `{"name":"gopher"}`, auth)
+		require.NoError(t, err)
+		assert.Equal(t, `POST /users {"name":"gopher"}`, body)
+		_, _, err = send(t, client, "POST", server.URL+"/users", `{"name":"rustacean"}`, nil)
+		require.NoError(t, err)
+		require.NoError(t, recorder.Save())
+		assert.Equal(t, 3, hits)
+
+		cassette, err := os.ReadFile("testdata/recorder.json")
+		require.NoError(t, err)
+		assert.NotContains(t, string(cassette), "Bearer token")
+		assert.NotContains(t, string(cassette), "secret")
+		assert.Contains(t, string(cassette), `"Authorization": [`+"\n"+`          "REDACTED"`)
+
+		server.Close()
+
+		// Replay the responses without the server, in the recorded order.
+		client = &http.Client{Transport: NewRecorder(t, "recorder")}
+		resp, body, err := send(t, client, "POST", "http://example.com/users", `{"name":"gopher"}`, nil)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, `POST /users {"name":"gopher"}`, body)
+		assert.Equal(t, "xx", resp.Header.Get("X-Hits"))
+		assert.Equal(t, Redacted, resp.Header.Get("Set-Cookie"))
+		_, body, err = send(t, client, "POST", "http://example.com/users", `{"name":"gopher"}`, nil)
+		require.NoError(t, err)
+		assert.Equal(t, `POST /users {"name":"rustacean"}`, body)
+		_, body, err = send(t, client, "GET", "http://example.com/users?name=gopher", "", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "GET /users?name=gopher ", body)
+
+		// Match the body and headers.
+		client = &http.Client{Transport: NewRecorder(t, "recorder",
+			WithMatch(DefaultMatch|MatchBody),
+			WithMatchHeaders("X-Version", "Authorization"),
+		)}
+		_, body, err = send(t, client, "POST", "http://example.com/users", `{"name":"gopher"}`,
+			http.Header{"Authorization": {"Bearer other"}, "X-Version": {"1"}})
+		require.NoError(t, err)
+		assert.Equal(t, `POST /users {"name":"gopher"}`, body)
+
+		mockT := new(captureT)
+		client = &http.Client{Transport: NewRecorder(mockT, "recorder", WithMatch(DefaultMatch|MatchBody))}
+		_, _, err = send(t, client, "POST", "http://example.com/users", `{"name":"ferris"}`, nil)
+		assert.Error(t, err)
+		require.Len(t, mockT.failures(), 1)
+		assert.Equal(t, "httpmock: no interaction recorded in testdata/recorder.json matches POST http://example.com/users", mockT.failures()[0])
+	})
+}
+
+func TestRecorderMissingCassette(t *testing.T) {
+	inTempDir(t, func() {
+		mockT := new(captureT)
+		NewRecorder(mockT, "missing")
+		require.Len(t, mockT.failures(), 1)
+		assert.Contains(t, mockT.failures()[0], "httpmock: cannot load cassette: open testdata/missing.json: ")
+	})
+}
+
+func TestRecorderInvalidCassette(t *testing.T) {
+	inTempDir(t, func() {
+		require.NoError(t, os.Mkdir("testdata", 0o755))
+		cassette := `[{"request":{"method":"GET","url":"http://example.com/users"},"response":{"status_code":"oops"}}]`
+		require.NoError(t, os.WriteFile("testdata/invalid.json", []byte(cassette), 0o644))
+
+		mockT := new(captureT)
+		client := &http.Client{Transport: NewRecorder(mockT, "invalid")}
+		require.Len(t, mockT.failures(), 1)
+		assert.Contains(t, mockT.failures()[0], "httpmock: cannot load cassette: testdata/invalid.json: ")
+
+		// The interactions decoded before the error are not replayed.
+		_, _, err := send(t, client, "GET", "http://example.com/users", "", nil)
+		assert.Error(t, err)
+		require.Len(t, mockT.failures(), 2)
+		assert.Contains(t, mockT.failures()[1], "httpmock: no interaction recorded in testdata/invalid.json matches GET http://example.com/users")
+	})
+}
@@ -0,0 +1,386 @@
+package httpmock
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// TestingT is an interface wrapper around *testing.T.
+type TestingT interface {
+	Errorf(format string, args ...interface{})
+}
+
+type tHelper = interface {
+	Helper()
+}
+
+// Server is an httptest.Server answering the requests matching its routes.
+type Server struct {
+	*httptest.Server
+
+	t    TestingT
+	mock mock.Mock
+
+	mu     sync.Mutex
+	routes []*Route
+}
+
+// NewServer starts a Server reporting unexpected requests to t. If t has
+// a Cleanup method, as *testing.T does, the server is closed when the test
+// ends.
+func NewServer(t TestingT) *Server {
+	s := &Server{t: t}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	if c, ok := t.(interface{ Cleanup(func()) }); ok {
+		c.Cleanup(s.Close)
+	}
+	return s
+}
+
+// On declares a route answering the requests with the given method and
+// URL path, responding 200 OK with an empty body unless configured
+// otherwise.
+//
+//	server.On("GET", "/users/42").RespondJSON(http.StatusOK, user)
+func (s *Server) On(method, path string) *Route {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := &Route{
+		server:         s,
+		method:         method,
+		path:           path,
+		header:         make(http.Header),
+		query:          make(url.Values),
+		status:         http.StatusOK,
+		responseHeader: make(http.Header),
+	}
+	r.call = s.mock.On(r.name())
+	s.routes = append(s.routes, r)
+	return r
+}
+
+// AssertExpectations asserts that every route that is not optional was
+// requested, as many times as specified with Once, Twice or Times.
+func (s *Server) AssertExpectations(t mock.TestingT) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return s.mock.AssertExpectations(t)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.t.Errorf("httpmock: reading the body of %s %s: %s", req.Method, req.URL, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	route, msg := s.match(req, body)
+	if route == nil {
+		s.t.Errorf("%s", msg)
+		http.Error(w, msg, http.StatusNotFound)
+		return
+	}
+	route.respond(w)
+}
+
+// match returns the route matching req and records the call, or a message
+// describing why no route matches.
+func (s *Server) match(req *http.Request, body []byte) (*Route, string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var closest *Route
+	var closestDiff string
+	closestCount := -1
+	for _, r := range s.routes {
+		expected, actual := r.arguments(req, body)
+		diff, count := expected.Diff(actual)
+		if count == 0 && !r.exhausted() {
+			r.calls++
+			s.mock.MethodCalled(r.name(), expected[2:]...)
+			return r, ""
+		}
+		if r.exhausted() {
+			// Prefer routes that can still be requested.
+			count++
+		}
+		if closestCount < 0 || count < closestCount {
+			closest, closestDiff, closestCount = r, diff, count
+		}
+	}
+
+	request := fmt.Sprintf("%s %s", req.Method, req.URL.RequestURI())
+	if closest == nil {
+		return nil, fmt.Sprintf("httpmock: unexpected request %s: no route declared with Server.On", request)
+	}
+	if closestDiff == "No differences." {
+		return nil, fmt.Sprintf("httpmock: unexpected request %s: the route %s was already requested %d time(s)", request, closest, closest.calls)
+	}
+	return nil, fmt.Sprintf("httpmock: unexpected request %s\n\nThe closest route is:\n\n%s\n\nDiff: %s", request, closest, strings.TrimSpace(closestDiff))
+}
+
+// Route is a request expected by a Server and the response to it.
+type Route struct {
+	server *Server
+	call   *mock.Call
+
+	method string
+	path   string
+	header http.Header
+	query  url.Values
+	body   *expectedBody
+
+	status         int
+	responseHeader http.Header
+	responseBody   []byte
+
+	// times is the maximum number of calls, or 0 for no maximum.
+	times int
+	calls int
+}
+
+// expectedBody is the body expected by a route.
+type expectedBody struct {
+	raw  string
+	json bool
+}
+
+// WithHeader restricts the route to requests having a header with the
+// given key and value.
+func (r *Route) WithHeader(key, value string) *Route {
+	return r.update(func() { r.header.Add(key, value) })
+}
+
+// WithQuery restricts the route to requests having a query parameter with
+// the given key and value.
+func (r *Route) WithQuery(key, value string) *Route {
+	return r.update(func() { r.query.Add(key, value) })
+}
+
+// WithBody restricts the route to requests with the given body.
+func (r *Route) WithBody(body string) *Route {
+	return r.update(func() { r.body = &expectedBody{raw: body} })
+}
+
+// WithJSONBody restricts the route to requests with a JSON body
+// equivalent to body, ignoring formatting and the order of object keys.
+// A string or []byte body is JSON text; any other value is marshaled with
+// encoding/json. It panics if body is not valid JSON.
+func (r *Route) WithJSONBody(body interface{}) *Route {
+	raw := toJSON(body)
+	if _, err := normalizeJSON([]byte(raw)); err != nil {
+		panic(fmt.Sprintf("httpmock: invalid JSON body %q: %s", raw, err))
+	}
+	return r.update(func() { r.body = &expectedBody{raw: raw, json: true} })
+}
+
+// Respond sets the status code and the body of the response.
+func (r *Route) Respond(status int, body string) *Route {
+	return r.update(func() {
+		r.status = status
+		r.responseBody = []byte(body)
+	})
+}
+
+// RespondJSON sets the status code and the JSON body of the response, and
+// its Content-Type header. A string or []byte body is JSON text; any other
+// value is marshaled with encoding/json.
+func (r *Route) RespondJSON(status int, body interface{}) *Route {
+	return r.update(func() {
+		r.status = status
+		r.responseBody = []byte(toJSON(body))
+		r.responseHeader.Set("Content-Type", "application/json")
+	})
+}
+
+// RespondHeader adds a header to the response.
+func (r *Route) RespondHeader(key, value string) *Route {
+	return r.update(func() { r.responseHeader.Add(key, value) })
+}
+
+// Once indicates that the route should only be requested once.
+func (r *Route) Once() *Route {
+	return r.Times(1)
+}
+
+// Twice indicates that the route should only be requested twice.
+func (r *Route) Twice() *Route {
+	return r.Times(2)
+}
+
+// Times indicates that the route should only be requested the indicated
+// number of times.
+func (r *Route) Times(i int) *Route {
+	return r.update(func() {
+		r.times = i
+		r.call.Times(i)
+	})
+}
+
+// Maybe allows the route not to be requested. Not requesting an optional
+// route will not cause an error while asserting expectations.
+func (r *Route) Maybe() *Route {
+	r.call.Maybe()
+	return r
+}
+
+func (r *Route) update(f func()) *Route {
+	r.server.mu.Lock()
+	defer r.server.mu.Unlock()
+	f()
+	r.call.Arguments = r.conditions()
+	return r
+}
+
+func (r *Route) String() string {
+	s := r.name()
+	if conditions := r.conditions(); len(conditions) > 0 {
+		lines := make([]string, len(conditions))
+		for i, c := range conditions {
+			lines[i] = "\t" + c.(string)
+		}
+		s += "\n" + strings.Join(lines, "\n")
+	}
+	return s
+}
+
+func (r *Route) name() string {
+	return r.method + " " + r.path
+}
+
+func (r *Route) exhausted() bool {
+	return r.times > 0 && r.calls >= r.times
+}
+
+// conditions describes the requirements of the route on the query, the
+// headers and the body of requests.
+func (r *Route) conditions() mock.Arguments {
+	var conditions mock.Arguments
+	for _, key := range sortedKeys(r.query) {
+		for _, value := range r.query[key] {
+			conditions = append(conditions, fmt.Sprintf("query %s=%s", key, value))
+		}
+	}
+	for _, key := range sortedKeys(r.header) {
+		for _, value := range r.header[key] {
+			conditions = append(conditions, fmt.Sprintf("header %s: %s", key, value))
+		}
+	}
+	if r.body != nil {
+		conditions = append(conditions, r.body.describe([]byte(r.body.raw)))
+	}
+	return conditions
+}
+
+// arguments returns the method, the path and the conditions of the route,
+// and the corresponding properties of req, for mock.Arguments.Diff.
+func (r *Route) arguments(req *http.Request, body []byte) (expected, actual mock.Arguments) {
+	expected = append(mock.Arguments{r.method, r.path}, r.conditions()...)
+	actual = mock.Arguments{req.Method, req.URL.Path}
+
+	query := req.URL.Query()
+	for _, key := range sortedKeys(r.query) {
+		for _, value := range r.query[key] {
+			actual = append(actual, fmt.Sprintf("query %s=%s", key, pick(query[key], value)))
+		}
+	}
+	for _, key := range sortedKeys(r.header) {
+		for _, value := range r.header[key] {
+			actual = append(actual, fmt.Sprintf("header %s: %s", key, pick(req.Header.Values(key), value)))
+		}
+	}
+	if r.body != nil {
+		actual = append(actual, r.body.describe(body))
+	}
+	return expected, actual
+}
+
+func (r *Route) respond(w http.ResponseWriter) {
+	r.server.mu.Lock()
+	status, body := r.status, r.responseBody
+	for key, values := range r.responseHeader {
+		w.Header()[key] = append([]string(nil), values...)
+	}
+	r.server.mu.Unlock()
+
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
+
+// describe returns body as compared to the expected body.
+func (b *expectedBody) describe(body []byte) string {
+	if !b.json {
+		return "body " + string(body)
+	}
+	normalized, err := normalizeJSON(body)
+	if err != nil {
+		return fmt.Sprintf("json body (invalid: %s) %s", err, body)
+	}
+	return "json body " + normalized
+}
+
+// pick returns expected if it is one of values, or values joined.
+func pick(values []string, expected string) string {
+	for _, v := range values {
+		if v == expected {
+			return v
+		}
+	}
+	if len(values) == 0 {
+		return "(missing)"
+	}
+	return strings.Join(values, ", ")
+}
+
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// toJSON returns the JSON text of body: body itself if it is a string or
+// a []byte, or body marshaled with encoding/json.
+func toJSON(body interface{}) string {
+	switch body := body.(type) {
+	case string:
+		return body
+	case []byte:
+		return string(body)
+	}
+	b, err := json.Marshal(body)
+	if err != nil {
+		panic(fmt.Sprintf("httpmock: cannot marshal %#v to JSON: %s", body, err))
+	}
+	return string(b)
+}
+
+// normalizeJSON returns data compacted, with the keys of objects sorted.
+func normalizeJSON(data []byte) (string, error) {
+	var v interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&v); err != nil {
+		return "", err
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -0,0 +1,159 @@
+package httpmock
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// captureT records the failures of a Server.
+type captureT struct {
+	mu     sync.Mutex
+	errors []string
+	logs   []string
+}
+
+func (t *captureT) Errorf(format string, args ...interface{}) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func (t *captureT) Logf(format string, args ...interface{}) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.logs = append(t.logs, fmt.Sprintf(format, args...))
+}
+
+func (t *captureT) FailNow() {}
+
+func (t *captureT) failures() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]string(nil), t.errors...)
+}
+
+func do(t *testing.T, method, url, contentType, body string) (int, string) {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	require.NoError(t, err)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(b)
+}
+
+func TestServer(t *testing.T) {
+	server := NewServer(t)
+
+	server.On("POST", "/users").
+		WithHeader("Content-Type", "application/json").
+		WithJSONBody(map[string]interface{}{"name": "gopher", "age": 13}).
+		RespondJSON(http.StatusCreated, map[string]interface{}{"id": 42}).
+		RespondHeader("Location", "/users/42").
+		Once()
+	server.On("GET", "/users").
+		WithQuery("name", "gopher").
+		Respond(http.StatusOK, "gopher")
+	server.On("DELETE", "/users/42").Maybe()
+
+	req, err := http.NewRequest("POST", server.URL+"/users", strings.NewReader(`{"age": 13, "name": "gopher"}`))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "/users/42", resp.Header.Get("Location"))
+	assert.JSONEq(t, `{"id": 42}`, string(body))
+
+	for i := 0; i < 2; i++ {
+		status, body := do(t, "GET", server.URL+"/users?name=gopher&limit=10", "", "")
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "gopher", body)
+	}
+
+	server.AssertExpectations(t)
+}
+
+func TestServerUnexpectedRequest(t *testing.T) {
+	mockT := new(captureT)
+	server := NewServer(mockT)
+	defer server.Close()
+
+	server.On("POST", "/users").
+		WithHeader("Content-Type", "application/json").
+		WithJSONBody(`{"name": "gopher"}`).
+		Once()
+	server.On("GET", "/users/42")
+
+	status, body := do(t, "POST", server.URL+"/users", "text/plain", `{"name": "rustacean"}`)
+	assert.Equal(t, http.StatusNotFound, status)
+	failures := mockT.failures()
+	require.Len(t, failures, 1)
+	assert.Equal(t, failures[0]+"\n", body)
+	for _, s := range []string{
+		"httpmock: unexpected request POST /users",
+		"The closest route is:\n\nPOST /users\n\theader Content-Type: application/json\n\tjson body {\"name\":\"gopher\"}",
+		"0: PASS:  (string=POST) == (string=POST)",
+		"1: PASS:  (string=/users) == (string=/users)",
+		"2: FAIL:  (string=header Content-Type: text/plain) != (string=header Content-Type: application/json)",
+		"3: FAIL:  (string=json body {\"name\":\"rustacean\"}) != (string=json body {\"name\":\"gopher\"})",
+	} {
+		assert.Contains(t, failures[0], s)
+	}
+
+	status, _ = do(t, "POST", server.URL+"/users", "application/json", `{"name":"gopher"}`)
+	assert.Equal(t, http.StatusOK, status)
+	status, _ = do(t, "POST", server.URL+"/users", "application/json", `{"name":"gopher"}`)
+	assert.Equal(t, http.StatusNotFound, status)
+	failures = mockT.failures()
+	require.Len(t, failures, 2)
+	assert.Contains(t, failures[1], "httpmock: unexpected request POST /users: the route POST /users")
+	assert.Contains(t, failures[1], "was already requested 1 time(s)")
+
+	empty := NewServer(mockT)
+	defer empty.Close()
+	status, _ = do(t, "GET", empty.URL+"/", "", "")
+	assert.Equal(t, http.StatusNotFound, status)
+	failures = mockT.failures()
+	require.Len(t, failures, 3)
+	assert.Equal(t, "httpmock: unexpected request GET /: no route declared with Server.On", failures[2])
+}
+
+func TestServerAssertExpectations(t *testing.T) {
+	server := NewServer(t)
+	server.On("GET", "/users/42").Twice()
+	server.On("GET", "/health").Maybe()
+
+	do(t, "GET", server.URL+"/users/42", "", "")
+
+	mockT := new(captureT)
+	assert.False(t, server.AssertExpectations(mockT))
+	require.Len(t, mockT.failures(), 1)
+	assert.Contains(t, mockT.failures()[0], "FAIL: 1 out of 2 expectation(s) were met.")
+
+	do(t, "GET", server.URL+"/users/42", "", "")
+	assert.True(t, server.AssertExpectations(t))
+}
+
+func TestRouteWithJSONBodyInvalid(t *testing.T) {
+	server := NewServer(t)
+	assert.PanicsWithValue(t, `httpmock: invalid JSON body "{": unexpected EOF`, func() {
+		server.On("POST", "/").WithJSONBody("{")
+	})
+}
@@ -0,0 +1,125 @@
+// Package goroutines inspects the goroutines of the program, to report the
+// stack of a goroutine or the goroutines leaked by a piece of code.
+package goroutines
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Goroutine is a goroutine running in the program.
+type Goroutine struct {
+	ID int64
+	// Stack is the stack trace of the goroutine, starting with a
+	// "goroutine <ID> [<state>]:" header.
+	Stack string
+}
+
+// CurrentID returns the id of the calling goroutine.
+func CurrentID() int64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	return parseID(buf)
+}
+
+// Stack returns the stack trace of the goroutine with the given id, or an
+// empty string if there is no such goroutine.
+func Stack(id int64) string {
+	for _, g := range All() {
+		if g.ID == id {
+			return g.Stack
+		}
+	}
+	return ""
+}
+
+// All returns the goroutines running in the program.
+func All() []Goroutine {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+
+	var all []Goroutine
+	for _, stack := range bytes.Split(buf, []byte("\n\n")) {
+		if id := parseID(stack); id != 0 {
+			all = append(all, Goroutine{ID: id, Stack: string(stack)})
+		}
+	}
+	return all
+}
+
+// parseID returns the id in the header of a stack trace, or 0.
+func parseID(stack []byte) int64 {
+	stack = bytes.TrimPrefix(stack, []byte("goroutine "))
+	if i := bytes.IndexByte(stack, ' '); i >= 0 {
+		stack = stack[:i]
+	}
+	id, _ := strconv.ParseInt(string(stack), 10, 64)
+	return id
+}
+
+// Snapshot is the set of the ids of the goroutines running at some point.
+type Snapshot map[int64]bool
+
+// Take returns the goroutines running now.
+func Take() Snapshot {
+	snapshot := make(Snapshot)
+	for _, g := range All() {
+		snapshot[g.ID] = true
+	}
+	return snapshot
+}
+
+// Leaked returns the goroutines started since s was taken that are still
+// running, waiting up to grace for them to exit. Goroutines whose stack
+// contains one of the ignore strings, such as the name of a function, are
+// not returned.
+func (s Snapshot) Leaked(grace time.Duration, ignore []string) []Goroutine {
+	deadline := time.Now().Add(grace)
+	for {
+		leaked := s.leaked(ignore)
+		if len(leaked) == 0 || !time.Now().Before(deadline) {
+			return leaked
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func (s Snapshot) leaked(ignore []string) []Goroutine {
+	var leaked []Goroutine
+	for _, g := range All() {
+		if s[g.ID] || ignored(g, ignore) {
+			continue
+		}
+		leaked = append(leaked, g)
+	}
+	return leaked
+}
+
+func ignored(g Goroutine, ignore []string) bool {
+	for _, pattern := range ignore {
+		if strings.Contains(g.Stack, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// Format returns the stack traces of goroutines separated by blank lines,
+// as in the output of a panic.
+func Format(goroutines []Goroutine) string {
+	stacks := make([]string, len(goroutines))
+	for i, g := range goroutines {
+		stacks[i] = g.Stack
+	}
+	return strings.Join(stacks, "\n\n")
+}
@@ -0,0 +1,63 @@
+package goroutines
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func startGoroutine(t *testing.T) (id int64, stop func()) {
+	ids := make(chan int64)
+	release := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ids <- CurrentID()
+		<-release
+	}()
+	return <-ids, func() {
+		close(release)
+		<-done
+	}
+}
+
+func TestStack(t *testing.T) {
+	id, stop := startGoroutine(t)
+	defer stop()
+
+	if id == CurrentID() {
+		t.Fatalf("CurrentID returned the same id %d for two goroutines", id)
+	}
+	stack := Stack(id)
+	if !strings.HasPrefix(stack, fmt.Sprintf("goroutine %d ", id)) {
+		t.Errorf("stack of goroutine %d has an unexpected header:\n%s", id, stack)
+	}
+	if !strings.Contains(stack, "startGoroutine") {
+		t.Errorf("stack of goroutine %d does not contain the function it runs:\n%s", id, stack)
+	}
+	if stack := Stack(-1); stack != "" {
+		t.Errorf("stack of a missing goroutine should be empty, got:\n%s", stack)
+	}
+}
+
+func TestLeaked(t *testing.T) {
+	snapshot := Take()
+	if leaked := snapshot.Leaked(0, nil); len(leaked) != 0 {
+		t.Errorf("unexpected leaked goroutines:\n%s", Format(leaked))
+	}
+
+	id, stop := startGoroutine(t)
+	leaked := snapshot.Leaked(20*time.Millisecond, nil)
+	if len(leaked) != 1 || leaked[0].ID != id {
+		t.Errorf("expected goroutine %d to be leaked, got:\n%s", id, Format(leaked))
+	}
+	if leaked := snapshot.Leaked(0, []string{"startGoroutine"}); len(leaked) != 0 {
+		t.Errorf("ignored goroutines should not be leaked, got:\n%s", Format(leaked))
+	}
+
+	time.AfterFunc(20*time.Millisecond, stop)
+	if leaked := snapshot.Leaked(time.Second, nil); len(leaked) != 0 {
+		t.Errorf("goroutines exiting during the grace period should not be leaked, got:\n%s", Format(leaked))
+	}
+}
@@ -0,0 +1,272 @@
+package mock
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/assert/yaml"
+)
+
+// expectationsFile is the format of the files read by LoadExpectations.
+type expectationsFile struct {
+	// InOrder requires the calls to be made in the order of the file.
+	InOrder bool               `yaml:"inOrder" json:"inOrder"`
+	Calls   []expectedCallSpec `yaml:"calls" json:"calls"`
+}
+
+type expectedCallSpec struct {
+	// Name identifies the call in the NotBefore list of other calls.
+	Name      string        `yaml:"name" json:"name"`
+	Method    string        `yaml:"method" json:"method"`
+	Arguments []interface{} `yaml:"arguments" json:"arguments"`
+	Returns   []interface{} `yaml:"returns" json:"returns"`
+	Times     int           `yaml:"times" json:"times"`
+	Maybe     bool          `yaml:"maybe" json:"maybe"`
+	Panic     *string       `yaml:"panic" json:"panic"`
+	NotBefore []string      `yaml:"notBefore" json:"notBefore"`
+}
+
+// LoadExpectations reads the expectations of the mock from a YAML or JSON
+// file, and sets them up as if by calls to Mock.On. It returns the calls
+// created, in the order of the file. If the file cannot be loaded, the
+// test set with Mock.Test fails, or LoadExpectations panics.
+//
+// obj is the *Mock itself or a pointer to the mocked object embedding the
+// Mock. In the latter case, the signatures of the methods of obj are used
+// to decode arguments and return values into the types of the parameters
+// and results; for example the string "boom" returned as an error becomes
+// errors.New("boom") and 42 returned as an int64 becomes int64(42).
+// Without a type hint, values are used as decoded.
+//
+//	inOrder: true
+//	calls:
+//	  - name: get
+//	    method: Get
+//	    arguments: [42, {anything: true}, {anythingOfType: string}, {regex: "^user-"}]
+//	    returns: [{name: Alice}, null]
+//	    times: 2
+//	  - method: Close
+//	    returns: [boom]
+//	    maybe: true
+//	    notBefore: [get]
+//
+// Arguments given as a map with a single key are matchers: "anything" for
+// Anything, "anythingOfType" for AnythingOfType, "regex" for a regular
+// expression the argument, formatted with %v, must match, and "value" for
+// a literal value that would otherwise be read as a matcher.
+//
+// The file is decoded with the [github.com/stretchr/testify/assert/yaml]
+// package.
+func LoadExpectations(obj interface{}, path string) []*Call {
+	m := mockOf(obj)
+	calls, err := loadExpectations(obj, m, path)
+	if err != nil {
+		m.fail("mock: cannot load expectations from %s: %s", path, err)
+	}
+	return calls
+}
+
+// mockOf returns obj if it is a *Mock, or the Mock embedded in it.
+func mockOf(obj interface{}) *Mock {
+	if m, ok := obj.(*Mock); ok {
+		return m
+	}
+	v := reflect.ValueOf(obj)
+	if v.Kind() == reflect.Ptr && v.Elem().Kind() == reflect.Struct {
+		field := v.Elem().FieldByName("Mock")
+		if field.IsValid() && field.Type() == reflect.TypeOf(Mock{}) {
+			return field.Addr().Interface().(*Mock)
+		}
+	}
+	panic(fmt.Sprintf("mock: %T is neither a *mock.Mock nor a pointer to a struct embedding mock.Mock", obj))
+}
+
+func loadExpectations(obj interface{}, m *Mock, path string) ([]*Call, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file expectationsFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+
+	// The methods of a bare *Mock are not those of the mocked object.
+	var objType reflect.Type
+	if _, ok := obj.(*Mock); !ok {
+		objType = reflect.TypeOf(obj)
+	}
+	calls := make([]*Call, 0, len(file.Calls))
+	named := make(map[string]*Call)
+	for i, spec := range file.Calls {
+		if spec.Method == "" {
+			return nil, fmt.Errorf("call %d: missing method", i)
+		}
+		var method reflect.Type
+		if objType != nil {
+			if m, ok := objType.MethodByName(spec.Method); ok {
+				method = m.Type
+			}
+		}
+
+		arguments := make([]interface{}, len(spec.Arguments))
+		for j, arg := range spec.Arguments {
+			// The first parameter of a method type is the receiver.
+			arguments[j], err = decodeArgument(arg, paramType(method, j+1))
+			if err != nil {
+				return nil, fmt.Errorf("call %d (%s): argument %d: %w", i, spec.Method, j, err)
+			}
+		}
+		returns := make([]interface{}, len(spec.Returns))
+		for j, ret := range spec.Returns {
+			var hint reflect.Type
+			if method != nil && j < method.NumOut() {
+				hint = method.Out(j)
+			}
+			returns[j], err = decodeValue(ret, hint)
+			if err != nil {
+				return nil, fmt.Errorf("call %d (%s): return value %d: %w", i, spec.Method, j, err)
+			}
+		}
+
+		call := newCall(m, spec.Method, assert.CallerInfo(), arguments, returns)
+		if spec.Times > 0 {
+			call.Times(spec.Times)
+		}
+		if spec.Maybe {
+			call.Maybe()
+		}
+		if spec.Panic != nil {
+			call.Panic(*spec.Panic)
+		}
+		for _, name := range spec.NotBefore {
+			before, ok := named[name]
+			if !ok {
+				return nil, fmt.Errorf("call %d (%s): notBefore: no call named %q before it", i, spec.Method, name)
+			}
+			call.NotBefore(before)
+		}
+		if spec.Name != "" {
+			if _, ok := named[spec.Name]; ok {
+				return nil, fmt.Errorf("call %d (%s): duplicate name %q", i, spec.Method, spec.Name)
+			}
+			named[spec.Name] = call
+		}
+		calls = append(calls, call)
+	}
+	if file.InOrder {
+		InOrder(calls...)
+	}
+
+	// The calls are only added once they are all valid.
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.ExpectedCalls = append(m.ExpectedCalls, calls...)
+	return calls, nil
+}
+
+// paramType returns the type of the i-th parameter of method, or nil if
+// unknown. A variadic parameter is expected as a single slice argument.
+func paramType(method reflect.Type, i int) reflect.Type {
+	if method == nil || i >= method.NumIn() {
+		return nil
+	}
+	return method.In(i)
+}
+
+// decodeArgument decodes an argument, which may be a matcher.
+func decodeArgument(arg interface{}, hint reflect.Type) (interface{}, error) {
+	matcher, ok := normalize(arg).(map[string]interface{})
+	if !ok || len(matcher) != 1 {
+		return decodeValue(arg, hint)
+	}
+	for key, value := range matcher {
+		switch key {
+		case "anything":
+			return Anything, nil
+		case "anythingOfType":
+			typ, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("anythingOfType: %v is not a string", value)
+			}
+			return AnythingOfType(typ), nil
+		case "regex":
+			expr, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("regex: %v is not a string", value)
+			}
+			re, err := regexp.Compile(expr)
+			if err != nil {
+				return nil, fmt.Errorf("regex: %w", err)
+			}
+			return MatchedBy(func(v interface{}) bool {
+				if b, ok := v.([]byte); ok {
+					return re.Match(b)
+				}
+				return re.MatchString(fmt.Sprint(v))
+			}), nil
+		case "value":
+			return decodeValue(value, hint)
+		}
+	}
+	return decodeValue(arg, hint)
+}
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// decodeValue converts a decoded YAML value to the type hint, if any.
+func decodeValue(value interface{}, hint reflect.Type) (interface{}, error) {
+	value = normalize(value)
+	switch {
+	case hint == nil:
+		return value, nil
+	case hint == errorType:
+		switch v := value.(type) {
+		case nil:
+			return nil, nil
+		case string:
+			return errors.New(v), nil
+		}
+		return nil, fmt.Errorf("cannot decode %v as an error, use a string", value)
+	case hint.Kind() == reflect.Interface:
+		return value, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	decoded := reflect.New(hint)
+	if err := json.Unmarshal(data, decoded.Interface()); err != nil {
+		return nil, fmt.Errorf("cannot decode %v as %s: %w", value, hint, err)
+	}
+	return decoded.Elem().Interface(), nil
+}
+
+// normalize converts the map[interface{}]interface{} values produced by
+// some YAML decoders to map[string]interface{}.
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, elem := range v {
+			m[fmt.Sprint(key)] = normalize(elem)
+		}
+		return m
+	case map[string]interface{}:
+		for key, elem := range v {
+			v[key] = normalize(elem)
+		}
+		return v
+	case []interface{}:
+		for i, elem := range v {
+			v[i] = normalize(elem)
+		}
+		return v
+	}
+	return value
+}
@@ -0,0 +1,105 @@
+package mock
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fixtureRecord struct {
+	Name string `json:"name"`
+	Age  int    `json:"age"`
+}
+
+type fixtureStore struct{ Mock }
+
+func (s *fixtureStore) Get(id int64, name string, tags ...string) (*fixtureRecord, error) {
+	args := s.Called(id, name, tags)
+	record, _ := args.Get(0).(*fixtureRecord)
+	return record, args.Error(1)
+}
+
+func (s *fixtureStore) Close() error {
+	return s.Called().Error(0)
+}
+
+func Test_LoadExpectations(t *testing.T) {
+	t.Parallel()
+
+	store := new(fixtureStore)
+	calls := LoadExpectations(store, "testdata/expectations.yaml")
+	assert.Len(t, calls, 3)
+	assert.Equal(t, calls, store.ExpectedCalls)
+	assert.Equal(t, 2, calls[0].Repeatability)
+
+	record, err := store.Get(42, "user-1", "a", "b")
+	assert.NoError(t, err)
+	assert.Equal(t, &fixtureRecord{Name: "Alice", Age: 30}, record)
+	_, err = store.Get(42, "user-2")
+	assert.NoError(t, err)
+
+	record, err = store.Get(7, "anyone", "a", "b")
+	assert.Nil(t, record)
+	assert.EqualError(t, err, "not found")
+
+	assert.EqualError(t, store.Close(), "boom")
+	store.AssertExpectations(t)
+}
+
+func Test_LoadExpectations_Order(t *testing.T) {
+	t.Parallel()
+
+	store := new(fixtureStore)
+	LoadExpectations(store, "testdata/expectations.yaml")
+	assert.Panics(t, func() {
+		_ = store.Close()
+	})
+}
+
+func Test_LoadExpectations_JSONWithoutHints(t *testing.T) {
+	t.Parallel()
+
+	m := new(Mock)
+	LoadExpectations(m, "testdata/expectations.json")
+	assert.Equal(t, Arguments{3}, m.MethodCalled("Count", "items"))
+	m.AssertExpectations(t)
+}
+
+func Test_LoadExpectations_Errors(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cases := map[string]string{
+		"missing method":   "calls: [{returns: [1]}]",
+		"unknown call":     "calls: [{method: A, notBefore: [b]}]",
+		"duplicate name":   "calls: [{name: a, method: A}, {name: a, method: B}]",
+		"invalid regex":    "calls: [{method: Get, arguments: [{regex: '('}]}]",
+		"wrong type":       "calls: [{method: Get, arguments: [not a number]}]",
+		"error not string": "calls: [{method: Close, returns: [[1]]}]",
+	}
+	for name, content := range cases {
+		path := filepath.Join(dir, name+".yaml")
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		mockT := new(MockTestingT)
+		store := new(fixtureStore)
+		store.Test(mockT)
+		assert.PanicsWithValue(t, mockTestingTFailNowCalled, func() {
+			LoadExpectations(store, path)
+		}, name)
+		assert.Equal(t, 1, mockT.errorfCount, name)
+		assert.Empty(t, store.ExpectedCalls, name)
+	}
+
+	assert.Panics(t, func() {
+		LoadExpectations(new(Mock), filepath.Join(dir, "missing.yaml"))
+	})
+	assert.Panics(t, func() {
+		LoadExpectations(errors.New("not a mock"), "testdata/expectations.yaml")
+	})
+}
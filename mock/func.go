@@ -0,0 +1,86 @@
+package mock
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Func sets the function pointed to by fnPtr to an implementation routing
+// its calls to m.MethodCalled with methodName, so that a dependency of a
+// function type can be mocked like a method:
+//
+//	type Fetcher func(ctx context.Context, key string) ([]byte, error)
+//
+//	var m mock.Mock
+//	var fetch Fetcher
+//	mock.Func(&m, "Fetch", &fetch)
+//	m.On("Fetch", mock.Anything, "key").Return([]byte("value"), nil)
+//
+// The arguments of a variadic function are passed to MethodCalled with
+// the variadic ones as a single slice. The values given to Call.Return
+// must be assignable to the results of the function; nil is the zero
+// value of results of interface, pointer, slice, map, channel and function
+// types. Any other value fails the test set with Mock.Test, or panics.
+//
+// Func panics if fnPtr is not a non-nil pointer to a function.
+func Func(m *Mock, methodName string, fnPtr interface{}) {
+	ptr := reflect.ValueOf(fnPtr)
+	if ptr.Kind() != reflect.Ptr || ptr.IsNil() || ptr.Elem().Kind() != reflect.Func {
+		panic(fmt.Sprintf("mock: Func: %T is not a pointer to a function", fnPtr))
+	}
+	fnType := ptr.Elem().Type()
+
+	fn := reflect.MakeFunc(fnType, func(in []reflect.Value) []reflect.Value {
+		arguments := make([]interface{}, len(in))
+		for i, arg := range in {
+			arguments[i] = arg.Interface()
+		}
+		returned := m.MethodCalled(methodName, arguments...)
+
+		out := make([]reflect.Value, fnType.NumOut())
+		for i := range out {
+			out[i] = reflect.Zero(fnType.Out(i))
+		}
+		if len(returned) != len(out) {
+			m.fail("\nassert: mock: %s returns %d value(s), but %d were given to Return.\n\tUse Mock.On(\"%s\").Return(...) with %s", methodName, len(out), len(returned), methodName, resultsString(fnType))
+			return out
+		}
+		for i, value := range returned {
+			if value == nil {
+				if !isNilable(fnType.Out(i).Kind()) {
+					m.fail("\nassert: mock: %s: return value %d is nil, which cannot be used as %s", methodName, i, fnType.Out(i))
+				}
+				continue
+			}
+			v := reflect.ValueOf(value)
+			if !v.Type().AssignableTo(fnType.Out(i)) {
+				m.fail("\nassert: mock: %s: return value %d is %s, which cannot be used as %s", methodName, i, v.Type(), fnType.Out(i))
+				continue
+			}
+			out[i] = reflect.New(fnType.Out(i)).Elem()
+			out[i].Set(v)
+		}
+		return out
+	})
+	ptr.Elem().Set(fn)
+}
+
+func isNilable(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Interface, reflect.Ptr, reflect.Slice, reflect.Map, reflect.Chan, reflect.Func:
+		return true
+	}
+	return false
+}
+
+// resultsString formats the results of fnType, as in "([]uint8, error)".
+func resultsString(fnType reflect.Type) string {
+	s := "("
+	for i := 0; i < fnType.NumOut(); i++ {
+		if i > 0 {
+			s += ", "
+		}
+		s += fnType.Out(i).String()
+	}
+	return s + ")"
+}
@@ -0,0 +1,96 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fetcher func(ctx context.Context, key string) ([]byte, error)
+
+func Test_Func(t *testing.T) {
+	t.Parallel()
+
+	var m Mock
+	var fetch fetcher
+	Func(&m, "Fetch", &fetch)
+	m.On("Fetch", Anything, "key").Return([]byte("value"), nil).Once()
+	m.On("Fetch", Anything, "missing").Return(nil, errors.New("not found")).Once()
+
+	value, err := fetch(context.Background(), "key")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+
+	value, err = fetch(context.Background(), "missing")
+	assert.Nil(t, value)
+	assert.EqualError(t, err, "not found")
+
+	m.AssertExpectations(t)
+	m.AssertCalled(t, "Fetch", Anything, "key")
+}
+
+func Test_Func_Variadic(t *testing.T) {
+	t.Parallel()
+
+	var m Mock
+	var format func(format string, args ...interface{}) string
+	Func(&m, "Format", &format)
+	m.On("Format", "%d-%d", []interface{}{1, 2}).Return("1-2")
+
+	assert.Equal(t, "1-2", format("%d-%d", 1, 2))
+}
+
+func Test_Func_NoResults(t *testing.T) {
+	t.Parallel()
+
+	var m Mock
+	var notify func(string)
+	Func(&m, "Notify", &notify)
+	m.On("Notify", "hello").Return()
+
+	notify("hello")
+	m.AssertNumberOfCalls(t, "Notify", 1)
+}
+
+func Test_Func_ReturnMismatch(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		returns  Arguments
+		expected string
+	}{
+		{Arguments{[]byte("value")}, "Fetch returns 2 value(s), but 1 were given to Return"},
+		{Arguments{"value", nil}, "Fetch: return value 0 is string, which cannot be used as []uint8"},
+	}
+	for _, c := range cases {
+		var m Mock
+		var fetch fetcher
+		Func(&m, "Fetch", &fetch)
+		m.On("Fetch", Anything, "key").Return(c.returns...)
+
+		func() {
+			defer func() {
+				assert.Contains(t, fmt.Sprint(recover()), c.expected)
+			}()
+			_, _ = fetch(context.Background(), "key")
+		}()
+	}
+
+	var m Mock
+	var count func() int
+	Func(&m, "Count", &count)
+	m.On("Count").Return(nil)
+	assert.Panics(t, func() { count() })
+}
+
+func Test_Func_InvalidPointer(t *testing.T) {
+	t.Parallel()
+
+	var m Mock
+	var notAFunc int
+	assert.Panics(t, func() { Func(&m, "Fn", &notAFunc) })
+	assert.Panics(t, func() { Func(&m, "Fn", func() {}) })
+}
@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"regexp"
 	"runtime"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -16,6 +17,7 @@ import (
 	"github.com/stretchr/objx"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/clock"
 )
 
 // regex for GCCGO functions
@@ -66,6 +68,9 @@ type Call struct {
 
 	waitTime time.Duration
 
+	// clock waits for waitTime, or the real clock if nil.
+	clock clock.Clock
+
 	// Holds a handler used to manipulate arguments content that are passed by
 	// reference. It's useful when mocking methods such as unmarshalers or
 	// decoders.
@@ -78,6 +83,13 @@ type Call struct {
 
 	// Calls which must be satisfied before this call can be
 	requires []*Call
+
+	// callThrough calls the method of the delegate of the mock instead of
+	// returning ReturnArguments.
+	callThrough bool
+
+	// notify receives the arguments of each call matching this one.
+	notify *notifier
 }
 
 func newCall(parent *Mock, methodName string, callerInfo []string, methodArguments Arguments, returnArguments Arguments) *Call {
@@ -169,6 +181,21 @@ func (c *Call) After(d time.Duration) *Call {
 	c.lock()
 	defer c.unlock()
 	c.waitTime = d
+	c.clock = nil
+	return c
+}
+
+// AfterClock sets how long to block until the call returns, as measured
+// by clk. Passing a clock.Fake lets the test control when the call
+// returns, without sleeping. To block until a deadline instead, use
+// WaitUntil(clk.After(d)).
+//
+//	Mock.On("MyMethod", arg1, arg2).AfterClock(fakeClock, time.Second)
+func (c *Call) AfterClock(clk clock.Clock, d time.Duration) *Call {
+	c.lock()
+	defer c.unlock()
+	c.waitTime = d
+	c.clock = clk
 	return c
 }
 
@@ -196,6 +223,65 @@ func (c *Call) Maybe() *Call {
 	return c
 }
 
+// CallThrough makes the call delegate to the same method of the object set
+// with Mock.Spy, returning its results instead of the values given to
+// Return. The results are recorded in Mock.Calls.
+//
+//	Mock.On("Save", mock.Anything).CallThrough().Once()
+func (c *Call) CallThrough() *Call {
+	c.lock()
+	defer c.unlock()
+	c.callThrough = true
+	return c
+}
+
+// notifyBuffer is the number of calls whose arguments are kept by the
+// channel returned by Call.Notify until they are received.
+const notifyBuffer = 100
+
+// Notify returns a channel receiving the arguments of each call matching
+// this expectation, in order. Calls to the mock never block on the
+// channel: it buffers the arguments of the last 100 calls not received
+// yet, older ones are dropped.
+//
+//	called := Mock.On("Publish", mock.Anything).Return(nil).Notify()
+//	go service.Run()
+//	args := <-called
+func (c *Call) Notify() <-chan Arguments {
+	c.lock()
+	defer c.unlock()
+	if c.notify == nil {
+		c.notify = &notifier{c: make(chan Arguments, notifyBuffer)}
+	}
+	return c.notify.c
+}
+
+// notifier sends the arguments of calls to c without blocking, dropping
+// the oldest arguments not received when c is full.
+type notifier struct {
+	c     chan Arguments
+	mutex sync.Mutex
+}
+
+func (n *notifier) send(arguments Arguments) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	arguments = append(Arguments(nil), arguments...)
+	for {
+		select {
+		case n.c <- arguments:
+			return
+		default:
+		}
+		// The channel is full: drop the oldest arguments, unless a
+		// receiver took them first.
+		select {
+		case <-n.c:
+		default:
+		}
+	}
+}
+
 // On chains a new expectation description onto the mocked interface. This
 // allows syntax like.
 //
@@ -293,9 +379,11 @@ func InOrder(calls ...*Call) {
 type Mock struct {
 	// Represents the calls that are expected of
 	// an object.
+	// Use ExpectedCallsSnapshot to read them while the mock may be called.
 	ExpectedCalls []*Call
 
 	// Holds the calls that were made to this mocked object.
+	// Use CallsSnapshot to read them while the mock may be called.
 	Calls []Call
 
 	// test is An optional variable that holds the test struct, to be used when an
@@ -306,6 +394,12 @@ type Mock struct {
 	// this data completely allowing you to do whatever you like with it.
 	testData objx.Map
 
+	// delegate is the real implementation set with Spy.
+	delegate interface{}
+
+	// called is broadcast when a call is recorded. Use callsChanged.
+	called *sync.Cond
+
 	mutex sync.Mutex
 }
 
@@ -327,6 +421,41 @@ func (m *Mock) TestData() objx.Map {
 	return m.testData
 }
 
+// CallsSnapshot returns a copy of Calls, the calls made to the mock so far.
+// Unlike Calls, it can be read and iterated over while the mock is called
+// from other goroutines.
+func (m *Mock) CallsSnapshot() []Call {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return snapshotCalls(m.Calls)
+}
+
+// ExpectedCallsSnapshot returns copies of the calls in ExpectedCalls, as
+// they are at the time of the call. Unlike ExpectedCalls, it can be read
+// and iterated over while the mock is called from other goroutines. Use
+// ExpectedCalls to modify the expectations.
+func (m *Mock) ExpectedCallsSnapshot() []Call {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	calls := make([]Call, len(m.ExpectedCalls))
+	for i, call := range m.ExpectedCalls {
+		calls[i] = *call
+	}
+	return snapshotCalls(calls)
+}
+
+// snapshotCalls copies calls, along with their arguments.
+func snapshotCalls(calls []Call) []Call {
+	snapshot := make([]Call, len(calls))
+	for i, call := range calls {
+		call.Arguments = append(Arguments(nil), call.Arguments...)
+		call.ReturnArguments = append(Arguments(nil), call.ReturnArguments...)
+		call.requires = append([]*Call(nil), call.requires...)
+		snapshot[i] = call
+	}
+	return snapshot
+}
+
 /*
 	Setting expectations
 */
@@ -338,6 +467,32 @@ func (m *Mock) Test(t TestingT) {
 	m.test = t
 }
 
+// callsChanged returns the condition variable broadcast when a call is
+// recorded. m.mutex must be held.
+func (m *Mock) callsChanged() *sync.Cond {
+	if m.called == nil {
+		m.called = sync.NewCond(&m.mutex)
+	}
+	return m.called
+}
+
+// Spy sets delegate as the real implementation of the mocked object,
+// turning the mock into a spy: calls matching no expectation are passed
+// through to the method of the same name of delegate, and expectations
+// using Call.CallThrough delegate too. The arguments and the real results
+// of these calls are recorded in Mock.Calls, so tests can assert on the
+// interactions with delegate while overriding only some of its methods.
+//
+//	repo := NewInMemoryRepository()
+//	m := new(MockRepository)
+//	m.Spy(repo)
+//	m.On("Delete", "id").Return(errors.New("denied"))
+func (m *Mock) Spy(delegate interface{}) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.delegate = delegate
+}
+
 // fail fails the current test with the given formatted format and args.
 // In case that a test was defined, it uses the test APIs for failing a test,
 // otherwise it uses panic.
@@ -486,16 +641,23 @@ func (m *Mock) Called(arguments ...interface{}) Arguments {
 // by appropriate .On .Return() calls)
 // If Call.WaitFor is set, blocks until the channel is closed or receives a message.
 func (m *Mock) MethodCalled(methodName string, arguments ...interface{}) Arguments {
-	m.mutex.Lock()
 	// TODO: could combine expected and closes in single loop
-	found, call := m.findExpectedCall(methodName, arguments...)
+	found, call, unlock := m.lockExpectedCall(methodName, arguments)
 
 	if found < 0 {
 		// expected call found, but it has already been called with repeatable times
 		if call != nil {
-			m.mutex.Unlock()
+			unlock()
 			m.fail("\nassert: mock: The method has been called over %d times.\n\tEither do one more Mock.On(\"%s\").Return(...), or remove extra call.\n\tThis call was unexpected:\n\t\t%s\n\tat: %s", call.totalCalls, methodName, callString(methodName, arguments, true), assert.CallerInfo())
 		}
+		// a spy passes the call through to its delegate
+		if call == nil && m.delegate != nil {
+			m.Calls = append(m.Calls, *newCall(m, methodName, assert.CallerInfo(), arguments, nil))
+			callIndex := len(m.Calls) - 1
+			m.callsChanged().Broadcast()
+			unlock()
+			return m.callThrough(callIndex, methodName, arguments)
+		}
 		// we have to fail here - because we don't know what to do
 		// as the return arguments.  This is because:
 		//
@@ -503,7 +665,7 @@ func (m *Mock) MethodCalled(methodName string, arguments ...interface{}) Argumen
 		//   b) the arguments are not what was expected, or
 		//   c) the developer has forgotten to add an accompanying On...Return pair.
 		closestCall, mismatch := m.findClosestCall(methodName, arguments...)
-		m.mutex.Unlock()
+		unlock()
 
 		if closestCall != nil {
 			m.fail("\n\nmock: Unexpected Method Call\n-----------------------------\n\n%s\n\nThe closest call I have is: \n\n%s\n\n%s\nDiff: %s\nat: %s\n",
@@ -518,20 +680,20 @@ func (m *Mock) MethodCalled(methodName string, arguments ...interface{}) Argumen
 		}
 	}
 
+	// the mocks of the requirements are locked too
 	for _, requirement := range call.requires {
 		if satisfied, _ := requirement.Parent.checkExpectation(requirement); !satisfied {
-			m.mutex.Unlock()
+			var another string
+			if requirement.totalCalls > 0 {
+				another = " another call of"
+			}
+			if call.Parent != requirement.Parent {
+				another += " method from another mock instance"
+			}
+			unlock()
 			m.fail("mock: Unexpected Method Call\n-----------------------------\n\n%s\n\nMust not be called before%s:\n\n%s",
 				callString(call.Method, call.Arguments, true),
-				func() (s string) {
-					if requirement.totalCalls > 0 {
-						s = " another call of"
-					}
-					if call.Parent != requirement.Parent {
-						s += " method from another mock instance"
-					}
-					return
-				}(),
+				another,
 				callString(requirement.Method, requirement.Arguments, true),
 			)
 		}
@@ -546,11 +708,18 @@ func (m *Mock) MethodCalled(methodName string, arguments ...interface{}) Argumen
 
 	// add the call
 	m.Calls = append(m.Calls, *newCall(m, methodName, assert.CallerInfo(), arguments, call.ReturnArguments))
-	m.mutex.Unlock()
+	callIndex := len(m.Calls) - 1
+	m.callsChanged().Broadcast()
+	if call.notify != nil {
+		call.notify.send(arguments)
+	}
+	unlock()
 
 	// block if specified
 	if call.WaitFor != nil {
 		<-call.WaitFor
+	} else if call.clock != nil {
+		call.clock.Sleep(call.waitTime)
 	} else {
 		time.Sleep(call.waitTime)
 	}
@@ -572,11 +741,151 @@ func (m *Mock) MethodCalled(methodName string, arguments ...interface{}) Argumen
 
 	m.mutex.Lock()
 	returnArgs := call.ReturnArguments
+	callThrough := call.callThrough
 	m.mutex.Unlock()
 
+	if callThrough {
+		return m.callThrough(callIndex, methodName, arguments)
+	}
 	return returnArgs
 }
 
+// lockExpectedCall locks the mock and finds the expected call matching
+// methodName and arguments, as findExpectedCall. The mocks of the calls
+// required by it with NotBefore are locked too, all in the order of their
+// addresses, so that concurrent calls on mocks requiring calls of each
+// other cannot deadlock. unlock unlocks all of them.
+func (m *Mock) lockExpectedCall(methodName string, arguments []interface{}) (found int, call *Call, unlock func()) {
+	mocks := []*Mock{m}
+	for {
+		unlock = lockMocks(mocks)
+		found, call = m.findExpectedCall(methodName, arguments...)
+		if call == nil {
+			return found, call, unlock
+		}
+		complete := true
+		for _, requirement := range call.requires {
+			if !containsMock(mocks, requirement.Parent) {
+				mocks = append(mocks, requirement.Parent)
+				complete = false
+			}
+		}
+		if complete {
+			return found, call, unlock
+		}
+		// The requirements may have changed while relocking, look again.
+		unlock()
+	}
+}
+
+// lockMocks locks mocks in the order of their addresses, and returns a
+// function unlocking them.
+func lockMocks(mocks []*Mock) (unlock func()) {
+	sorted := append([]*Mock(nil), mocks...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return reflect.ValueOf(sorted[i]).Pointer() < reflect.ValueOf(sorted[j]).Pointer()
+	})
+	for _, m := range sorted {
+		m.mutex.Lock()
+	}
+	return func() {
+		for i := len(sorted) - 1; i >= 0; i-- {
+			sorted[i].mutex.Unlock()
+		}
+	}
+}
+
+func containsMock(mocks []*Mock, m *Mock) bool {
+	for _, other := range mocks {
+		if other == m {
+			return true
+		}
+	}
+	return false
+}
+
+// callThrough calls the method of the delegate, and records its results
+// as the return arguments of the callIndex-th call.
+func (m *Mock) callThrough(callIndex int, methodName string, arguments Arguments) Arguments {
+	m.mutex.Lock()
+	delegate := m.delegate
+	m.mutex.Unlock()
+
+	if delegate == nil {
+		m.fail("\nassert: mock: Cannot call %s through: no delegate.\n\tUse Mock.Spy(...) to set the real implementation.", methodName)
+		return nil
+	}
+	method := reflect.ValueOf(delegate).MethodByName(methodName)
+	if !method.IsValid() {
+		m.fail("\nassert: mock: Cannot call %s through: %T has no method %s.", methodName, delegate, methodName)
+		return nil
+	}
+	in, variadicSlice, err := callThroughArguments(method.Type(), arguments)
+	if err != nil {
+		m.fail("\nassert: mock: Cannot call %s through to %T: %s.\n\tThis call was:\n\t\t%s", methodName, delegate, err, callString(methodName, arguments, true))
+		return nil
+	}
+
+	var out []reflect.Value
+	if variadicSlice {
+		out = method.CallSlice(in)
+	} else {
+		out = method.Call(in)
+	}
+	results := make(Arguments, len(out))
+	for i, v := range out {
+		results[i] = v.Interface()
+	}
+
+	m.mutex.Lock()
+	m.Calls[callIndex].ReturnArguments = results
+	m.mutex.Unlock()
+	return results
+}
+
+// callThroughArguments converts the arguments of a call to the parameters
+// of fnType. The arguments of a variadic function may either be spread or
+// passed as a single slice, in which case variadicSlice is true.
+func callThroughArguments(fnType reflect.Type, arguments Arguments) (in []reflect.Value, variadicSlice bool, err error) {
+	numIn := fnType.NumIn()
+	if fnType.IsVariadic() && len(arguments) == numIn {
+		last := arguments[numIn-1]
+		variadicSlice = last == nil || reflect.TypeOf(last).AssignableTo(fnType.In(numIn-1))
+	}
+	switch {
+	case variadicSlice, !fnType.IsVariadic():
+		if len(arguments) != numIn {
+			return nil, false, fmt.Errorf("%d argument(s) given, %d expected", len(arguments), numIn)
+		}
+	case len(arguments) < numIn-1:
+		return nil, false, fmt.Errorf("%d argument(s) given, at least %d expected", len(arguments), numIn-1)
+	}
+
+	in = make([]reflect.Value, len(arguments))
+	for i, arg := range arguments {
+		var typ reflect.Type
+		if i >= numIn-1 && fnType.IsVariadic() && !variadicSlice {
+			typ = fnType.In(numIn - 1).Elem()
+		} else {
+			typ = fnType.In(i)
+		}
+		if arg == nil {
+			if !isNilable(typ.Kind()) {
+				return nil, false, fmt.Errorf("argument %d is nil, which cannot be used as %s", i, typ)
+			}
+			in[i] = reflect.Zero(typ)
+			continue
+		}
+		v := reflect.ValueOf(arg)
+		if !v.Type().AssignableTo(typ) {
+			return nil, false, fmt.Errorf("argument %d is %s, which cannot be used as %s", i, v.Type(), typ)
+		}
+		in[i] = reflect.New(typ).Elem()
+		in[i].Set(v)
+	}
+	return in, variadicSlice, nil
+}
+
 /*
 	Assertions
 */
@@ -638,6 +947,74 @@ func (m *Mock) AssertExpectations(t TestingT) bool {
 	return failedExpectations == 0
 }
 
+// AssertExpectationsWithin asserts that everything specified with On and
+// Return was in fact called as expected, waiting up to timeout for calls
+// made from other goroutines.
+func (m *Mock) AssertExpectationsWithin(t TestingT, timeout time.Duration) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	m.mutex.Lock()
+	m.waitUntil(timeout, func() bool {
+		for _, expectedCall := range m.expectedCalls() {
+			if satisfied, _ := m.checkExpectation(expectedCall); !satisfied {
+				return false
+			}
+		}
+		return true
+	})
+	m.mutex.Unlock()
+
+	return m.AssertExpectations(t)
+}
+
+// WaitForCall asserts that the method is called with the arguments,
+// waiting up to timeout for it to be called from another goroutine.
+//
+//	go service.Start()
+//	Mock.WaitForCall(t, "Publish", time.Second, mock.Anything)
+func (m *Mock) WaitForCall(t TestingT, methodName string, timeout time.Duration, arguments ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	m.mutex.Lock()
+	called := m.waitUntil(timeout, func() bool {
+		return m.methodWasCalled(methodName, arguments)
+	})
+	m.mutex.Unlock()
+	if called {
+		return true
+	}
+
+	if !m.AssertCalled(t, methodName, arguments...) {
+		t.Logf("waited %s for %q to be called", timeout, methodName)
+		return false
+	}
+	return true
+}
+
+// waitUntil waits until done returns true, or timeout elapses, checking
+// it each time a call is recorded. m.mutex must be held.
+func (m *Mock) waitUntil(timeout time.Duration, done func() bool) bool {
+	called := m.callsChanged()
+	expired := false
+	timer := time.AfterFunc(timeout, func() {
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+		expired = true
+		called.Broadcast()
+	})
+	defer timer.Stop()
+
+	for !done() {
+		if expired {
+			return false
+		}
+		called.Wait()
+	}
+	return true
+}
+
 func (m *Mock) checkExpectation(call *Call) (bool, string) {
 	if !call.optional && !m.methodWasCalled(call.Method, call.Arguments) && call.totalCalls == 0 {
 		return false, fmt.Sprintf("FAIL:\t%s(%s)\n\t\tat: %s", call.Method, call.Arguments.String(), call.callerInfo)
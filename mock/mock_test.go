@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/clock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -766,6 +767,31 @@ func Test_Mock_Return_After(t *testing.T) {
 
 }
 
+func Test_Mock_Return_AfterClock(t *testing.T) {
+	fake := clock.NewFake(time.Now())
+	var mockedService = new(TestExampleImplementation)
+	mockedService.Mock.
+		On("TheExampleMethod", 1, 2, 3).
+		Return(1, nil).
+		AfterClock(fake, time.Minute)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = mockedService.TheExampleMethod(1, 2, 3)
+	}()
+
+	fake.BlockUntil(1)
+	fake.Advance(59 * time.Second)
+	select {
+	case <-done:
+		t.Fatal("call returned before the fake clock was advanced")
+	default:
+	}
+	fake.Advance(time.Second)
+	<-done
+}
+
 func Test_Mock_Return_Run(t *testing.T) {
 
 	// make a test impl object
@@ -1961,6 +1987,182 @@ func Test_MockReturnAndCalledConcurrent(t *testing.T) {
 	wg.Wait()
 }
 
+func Test_Mock_WaitForCall(t *testing.T) {
+	t.Parallel()
+
+	m := new(Mock)
+	m.On("Publish", Anything).Return()
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		m.MethodCalled("Publish", "event")
+	}()
+	assert.True(t, m.WaitForCall(t, "Publish", time.Second, "event"))
+
+	mockT := new(MockTestingT)
+	start := time.Now()
+	assert.False(t, m.WaitForCall(mockT, "Publish", 20*time.Millisecond, "other"))
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(20*time.Millisecond))
+	assert.Equal(t, 1, mockT.errorfCount)
+	assert.Equal(t, 1, mockT.logfCount)
+}
+
+func Test_Mock_AssertExpectationsWithin(t *testing.T) {
+	t.Parallel()
+
+	m := new(Mock)
+	m.On("Start").Return()
+	m.On("Stop").Return().Once()
+
+	go func() {
+		m.MethodCalled("Start")
+		time.Sleep(10 * time.Millisecond)
+		m.MethodCalled("Stop")
+	}()
+	assert.True(t, m.AssertExpectationsWithin(t, time.Second))
+
+	m.On("Restart").Return()
+	mockT := new(MockTestingT)
+	assert.False(t, m.AssertExpectationsWithin(mockT, 20*time.Millisecond))
+	assert.Equal(t, 1, mockT.errorfCount)
+}
+
+func Test_Call_Notify(t *testing.T) {
+	t.Parallel()
+
+	m := new(Mock)
+	called := m.On("Publish", Anything).Return().Notify()
+	assert.Equal(t, called, m.ExpectedCalls[0].Notify())
+
+	// Calls do not block while nothing receives.
+	for i := 0; i < 3; i++ {
+		m.MethodCalled("Publish", i)
+	}
+	for i := 0; i < 3; i++ {
+		select {
+		case args := <-called:
+			assert.Equal(t, Arguments{i}, args)
+		case <-time.After(time.Second):
+			t.Fatalf("call %d not notified", i)
+		}
+	}
+
+	go m.MethodCalled("Publish", 3)
+	select {
+	case args := <-called:
+		assert.Equal(t, Arguments{3}, args)
+	case <-time.After(time.Second):
+		t.Fatal("call not notified")
+	}
+}
+
+func Test_Call_Notify_NotReceived(t *testing.T) {
+	m := new(Mock)
+	called := m.On("Publish", Anything).Return().Notify()
+
+	// Not receiving from the channel does not leave goroutines behind.
+	assert.NoGoroutineLeaks(t, func() {
+		for i := 0; i < notifyBuffer+10; i++ {
+			m.MethodCalled("Publish", i)
+		}
+	})
+
+	// The arguments of the oldest calls are dropped.
+	require.Len(t, called, notifyBuffer)
+	assert.Equal(t, Arguments{10}, <-called)
+}
+
+func Test_Mock_Snapshots(t *testing.T) {
+	t.Parallel()
+
+	m := new(Mock)
+	m.On("Do", 1).Return("one")
+	m.MethodCalled("Do", 1)
+
+	calls := m.CallsSnapshot()
+	expectedCalls := m.ExpectedCallsSnapshot()
+	calls[0].Arguments[0] = 2
+	expectedCalls[0].ReturnArguments[0] = "two"
+
+	assert.Equal(t, Arguments{1}, m.Calls[0].Arguments)
+	assert.Equal(t, Arguments{"one"}, m.ExpectedCalls[0].ReturnArguments)
+	assert.Equal(t, "Do", expectedCalls[0].Method)
+	assert.Equal(t, 1, expectedCalls[0].totalCalls)
+}
+
+// Test that concurrent calls to mocks requiring calls of each other, while
+// the calls are inspected, neither race nor deadlock.
+func Test_Mock_ConcurrentCrossMockRequirements(t *testing.T) {
+	t.Parallel()
+
+	iterations := 500
+	a, b := new(Mock), new(Mock)
+	initA := a.On("Init").Return()
+	initB := b.On("Init").Return()
+	a.On("Do").Return().NotBefore(initB)
+	b.On("Do").Return().NotBefore(initA)
+	a.MethodCalled("Init")
+	b.MethodCalled("Init")
+
+	var wg sync.WaitGroup
+	for _, m := range []*Mock{a, b} {
+		m := m
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				m.MethodCalled("Do")
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				for _, call := range m.CallsSnapshot() {
+					_ = call.Method
+				}
+				for _, call := range m.ExpectedCallsSnapshot() {
+					_ = call.Repeatability
+				}
+			}
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("concurrent calls deadlocked")
+	}
+
+	a.AssertExpectations(t)
+	b.AssertExpectations(t)
+	a.AssertNumberOfCalls(t, "Do", iterations)
+	b.AssertNumberOfCalls(t, "Do", iterations)
+}
+
+func Test_LockMocks_Order(t *testing.T) {
+	t.Parallel()
+
+	a, b := new(Mock), new(Mock)
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			lockMocks([]*Mock{a, b})()
+		}()
+		go func() {
+			defer wg.Done()
+			lockMocks([]*Mock{b, a})()
+		}()
+	}
+	wg.Wait()
+}
+
 type timer struct{ Mock }
 
 func (s *timer) GetTime(i int) string {
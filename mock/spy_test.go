@@ -0,0 +1,120 @@
+package mock
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryRepository struct {
+	items map[string]string
+}
+
+func (r *memoryRepository) Save(key, value string) error {
+	r.items[key] = value
+	return nil
+}
+
+func (r *memoryRepository) Load(key string) (string, bool) {
+	value, ok := r.items[key]
+	return value, ok
+}
+
+func (r *memoryRepository) Keys(prefix string, limit ...int) []string {
+	return append([]string{prefix}, "n")[:1+len(limit)]
+}
+
+type spyRepository struct{ Mock }
+
+func (r *spyRepository) Save(key, value string) error {
+	return r.Called(key, value).Error(0)
+}
+
+func (r *spyRepository) Load(key string) (string, bool) {
+	args := r.Called(key)
+	return args.String(0), args.Bool(1)
+}
+
+func Test_Mock_Spy(t *testing.T) {
+	t.Parallel()
+
+	repo := &memoryRepository{items: map[string]string{}}
+	spy := new(spyRepository)
+	spy.Spy(repo)
+	spy.On("Save", "denied", Anything).Return(errors.New("denied")).Once()
+
+	assert.NoError(t, spy.Save("a", "1"))
+	assert.EqualError(t, spy.Save("denied", "2"), "denied")
+	value, ok := spy.Load("a")
+	assert.True(t, ok)
+	assert.Equal(t, "1", value)
+	_, ok = spy.Load("denied")
+	assert.False(t, ok)
+
+	assert.Equal(t, map[string]string{"a": "1"}, repo.items)
+	spy.AssertExpectations(t)
+	spy.AssertCalled(t, "Save", "a", "1")
+	spy.AssertNumberOfCalls(t, "Load", 2)
+	if assert.Len(t, spy.Calls, 4) {
+		assert.Equal(t, Arguments{nil}, spy.Calls[0].ReturnArguments)
+		assert.Equal(t, Arguments{"1", true}, spy.Calls[2].ReturnArguments)
+		assert.Equal(t, Arguments{"", false}, spy.Calls[3].ReturnArguments)
+	}
+
+	// The expectation was used once.
+	assert.Panics(t, func() { _ = spy.Save("denied", "3") })
+}
+
+func Test_Call_CallThrough(t *testing.T) {
+	t.Parallel()
+
+	repo := &memoryRepository{items: map[string]string{"a": "1"}}
+	m := new(spyRepository)
+	m.Spy(repo)
+	m.On("Load", "a").CallThrough().Once()
+
+	value, ok := m.Load("a")
+	assert.True(t, ok)
+	assert.Equal(t, "1", value)
+	m.AssertExpectations(t)
+	assert.Equal(t, Arguments{"1", true}, m.Calls[0].ReturnArguments)
+}
+
+func Test_Call_CallThrough_Variadic(t *testing.T) {
+	t.Parallel()
+
+	m := new(Mock)
+	m.Spy(&memoryRepository{})
+
+	assert.Equal(t, Arguments{[]string{"p"}}, m.MethodCalled("Keys", "p"))
+	assert.Equal(t, Arguments{[]string{"p", "n"}}, m.MethodCalled("Keys", "p", 1))
+	assert.Equal(t, Arguments{[]string{"p", "n"}}, m.MethodCalled("Keys", "p", []int{1}))
+}
+
+func Test_Call_CallThrough_Errors(t *testing.T) {
+	t.Parallel()
+
+	m := new(Mock)
+	m.On("Load", "a").CallThrough()
+	assert.PanicsWithValue(t, "\nassert: mock: Cannot call Load through: no delegate.\n\tUse Mock.Spy(...) to set the real implementation.", func() {
+		m.MethodCalled("Load", "a")
+	})
+
+	m = new(Mock)
+	m.Spy(&memoryRepository{})
+	assert.PanicsWithValue(t, "\nassert: mock: Cannot call Delete through: *mock.memoryRepository has no method Delete.", func() {
+		m.MethodCalled("Delete", "a")
+	})
+
+	mockT := new(MockTestingT)
+	m.Test(mockT)
+	assert.PanicsWithValue(t, mockTestingTFailNowCalled, func() {
+		m.MethodCalled("Load", 1)
+	})
+	assert.Equal(t, 1, mockT.errorfCount)
+	assert.PanicsWithValue(t, mockTestingTFailNowCalled, func() {
+		m.MethodCalled("Save", "a")
+	})
+	assert.Equal(t, 2, mockT.errorfCount)
+}
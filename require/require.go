@@ -3,12 +3,42 @@
 package require
 
 import (
+	context "context"
 	assert "github.com/stretchr/testify/assert"
+	clock "github.com/stretchr/testify/clock"
 	http "net/http"
 	url "net/url"
 	time "time"
 )
 
+// ChannelClosed asserts that the specified channel is closed, with no value
+// left to receive, within timeout.
+//
+//	require.ChannelClosed(t, done, time.Second)
+func ChannelClosed(t TestingT, ch interface{}, timeout time.Duration, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.ChannelClosed(t, ch, timeout, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// ChannelClosedf asserts that the specified channel is closed, with no value
+// left to receive, within timeout.
+//
+//	require.ChannelClosedf(t, done, time.Second, "error message %s", "formatted")
+func ChannelClosedf(t TestingT, ch interface{}, timeout time.Duration, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.ChannelClosedf(t, ch, timeout, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
 // Condition uses a Comparison to assert a complex condition.
 func Condition(t TestingT, comp assert.Comparison, msgAndArgs ...interface{}) {
 	if h, ok := t.(tHelper); ok {
@@ -401,6 +431,100 @@ func Eventually(t TestingT, condition func() bool, waitFor time.Duration, tick t
 	t.FailNow()
 }
 
+// EventuallyClock is like Eventually, but waits on clk instead of the real
+// clock, so that it can be driven by a clock.Fake.
+//
+//	require.EventuallyClock(t, fakeClock, func() bool { return true; }, time.Second, 10*time.Millisecond)
+func EventuallyClock(t TestingT, clk clock.Clock, condition func() bool, waitFor time.Duration, tick time.Duration, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.EventuallyClock(t, clk, condition, waitFor, tick, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// EventuallyClockf is like Eventually, but waits on clk instead of the real
+// clock, so that it can be driven by a clock.Fake.
+//
+//	require.EventuallyClockf(t, fakeClock, func() bool { return true; }, time.Second, 10*time.Millisecond, "error message %s", "formatted")
+func EventuallyClockf(t TestingT, clk clock.Clock, condition func() bool, waitFor time.Duration, tick time.Duration, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.EventuallyClockf(t, clk, condition, waitFor, tick, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
+// EventuallyContext asserts that given condition will be met before ctx is
+// done, checking it tick after the end of each attempt. The condition
+// receives ctx, to abort its work when ctx is done. The assertion waits
+// for the running condition to return before reporting.
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+//	defer cancel()
+//	require.EventuallyContext(t, ctx, func(ctx context.Context) bool { return ping(ctx) == nil }, 10*time.Millisecond)
+func EventuallyContext(t TestingT, ctx context.Context, condition func(ctx context.Context) bool, tick time.Duration, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.EventuallyContext(t, ctx, condition, tick, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// EventuallyContextf asserts that given condition will be met before ctx is
+// done, checking it tick after the end of each attempt. The condition
+// receives ctx, to abort its work when ctx is done. The assertion waits
+// for the running condition to return before reporting.
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+//	defer cancel()
+//	require.EventuallyContextf(t, ctx, func(ctx context.Context) bool { return ping(ctx) == nil }, 10*time.Millisecond, "error message %s", "formatted")
+func EventuallyContextf(t TestingT, ctx context.Context, condition func(ctx context.Context) bool, tick time.Duration, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.EventuallyContextf(t, ctx, condition, tick, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
+// EventuallyWithPolling asserts that given condition will be met in waitFor
+// time, checking it as configured by polling, for example with exponential
+// backoff.
+//
+//	require.EventuallyWithPolling(t, func() bool { return true; }, time.Second, require.Polling{Tick: time.Millisecond, Backoff: 2, Immediate: true})
+func EventuallyWithPolling(t TestingT, condition func() bool, waitFor time.Duration, polling assert.Polling, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.EventuallyWithPolling(t, condition, waitFor, polling, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// EventuallyWithPollingf asserts that given condition will be met in waitFor
+// time, checking it as configured by polling, for example with exponential
+// backoff.
+//
+//	require.EventuallyWithPollingf(t, func() bool { return true; }, time.Second, require.Polling{Tick: time.Millisecond, Backoff: 2, Immediate: true}, "error message %s", "formatted")
+func EventuallyWithPollingf(t TestingT, condition func() bool, waitFor time.Duration, polling assert.Polling, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.EventuallyWithPollingf(t, condition, waitFor, polling, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
 // EventuallyWithT asserts that given condition will be met in waitFor time,
 // periodically checking target function each tick. In contrast to Eventually,
 // it supplies a CollectT to the condition function, so that the condition
@@ -429,6 +553,80 @@ func EventuallyWithT(t TestingT, condition func(collect *assert.CollectT), waitF
 	t.FailNow()
 }
 
+// EventuallyWithTClock is like EventuallyWithT, but waits on clk instead
+// of the real clock, so that it can be driven by a clock.Fake.
+//
+//	require.EventuallyWithTClock(t, fakeClock, func(c *require.CollectT) {
+//		require.True(c, externalValue, "expected 'externalValue' to be true")
+//	}, 10*time.Second, 1*time.Second)
+func EventuallyWithTClock(t TestingT, clk clock.Clock, condition func(collect *assert.CollectT), waitFor time.Duration, tick time.Duration, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.EventuallyWithTClock(t, clk, condition, waitFor, tick, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// EventuallyWithTClockf is like EventuallyWithT, but waits on clk instead
+// of the real clock, so that it can be driven by a clock.Fake.
+//
+//	require.EventuallyWithTClockf(t, fakeClock, func(c *require.CollectT, "error message %s", "formatted") {
+//		require.True(c, externalValue, "expected 'externalValue' to be true")
+//	}, 10*time.Second, 1*time.Second)
+func EventuallyWithTClockf(t TestingT, clk clock.Clock, condition func(collect *assert.CollectT), waitFor time.Duration, tick time.Duration, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.EventuallyWithTClockf(t, clk, condition, waitFor, tick, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
+// EventuallyWithTContext is like EventuallyWithT, but checks the condition
+// until ctx is done instead of for a fixed duration. The condition
+// receives ctx, to abort its work when ctx is done. The assertion waits
+// for the running condition to return before reporting.
+//
+//	require.EventuallyWithTContext(t, ctx, func(ctx context.Context, c *require.CollectT) {
+//		resp, err := client.Get(ctx, key)
+//		if require.NoError(c, err) {
+//			require.Equal(c, "value", resp)
+//		}
+//	}, 10*time.Millisecond)
+func EventuallyWithTContext(t TestingT, ctx context.Context, condition func(ctx context.Context, collect *assert.CollectT), tick time.Duration, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.EventuallyWithTContext(t, ctx, condition, tick, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// EventuallyWithTContextf is like EventuallyWithT, but checks the condition
+// until ctx is done instead of for a fixed duration. The condition
+// receives ctx, to abort its work when ctx is done. The assertion waits
+// for the running condition to return before reporting.
+//
+//	require.EventuallyWithTContextf(t, ctx, func(ctx context.Context, c *require.CollectT, "error message %s", "formatted") {
+//		resp, err := client.Get(ctx, key)
+//		if require.NoError(c, err) {
+//			require.Equal(c, "value", resp)
+//		}
+//	}, 10*time.Millisecond)
+func EventuallyWithTContextf(t TestingT, ctx context.Context, condition func(ctx context.Context, collect *assert.CollectT), tick time.Duration, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.EventuallyWithTContextf(t, ctx, condition, tick, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
 // EventuallyWithTf asserts that given condition will be met in waitFor time,
 // periodically checking target function each tick. In contrast to Eventually,
 // it supplies a CollectT to the condition function, so that the condition
@@ -457,6 +655,36 @@ func EventuallyWithTf(t TestingT, condition func(collect *assert.CollectT), wait
 	t.FailNow()
 }
 
+// EventuallyWithValue asserts that given condition will be met in waitFor
+// time, checking it as configured by polling. The condition also returns
+// the value it observed, which is reported if the condition is never met.
+//
+//	require.EventuallyWithValue(t, func() (bool, interface{}) { n := queue.Len(); return n == 0, n }, time.Second, require.Polling{Tick: 10*time.Millisecond})
+func EventuallyWithValue(t TestingT, condition func() (bool, interface{}), waitFor time.Duration, polling assert.Polling, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.EventuallyWithValue(t, condition, waitFor, polling, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// EventuallyWithValuef asserts that given condition will be met in waitFor
+// time, checking it as configured by polling. The condition also returns
+// the value it observed, which is reported if the condition is never met.
+//
+//	require.EventuallyWithValuef(t, func() (bool, interface{}) { n := queue.Len(); return n == 0, n }, time.Second, require.Polling{Tick: 10*time.Millisecond}, "error message %s", "formatted")
+func EventuallyWithValuef(t TestingT, condition func() (bool, interface{}), waitFor time.Duration, polling assert.Polling, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.EventuallyWithValuef(t, condition, waitFor, polling, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
 // Eventuallyf asserts that given condition will be met in waitFor time,
 // periodically checking target function each tick.
 //
@@ -1277,6 +1505,66 @@ func Never(t TestingT, condition func() bool, waitFor time.Duration, tick time.D
 	t.FailNow()
 }
 
+// NeverClock is like Never, but waits on clk instead of the real clock, so
+// that it can be driven by a clock.Fake.
+//
+//	require.NeverClock(t, fakeClock, func() bool { return false; }, time.Second, 10*time.Millisecond)
+func NeverClock(t TestingT, clk clock.Clock, condition func() bool, waitFor time.Duration, tick time.Duration, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.NeverClock(t, clk, condition, waitFor, tick, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// NeverClockf is like Never, but waits on clk instead of the real clock, so
+// that it can be driven by a clock.Fake.
+//
+//	require.NeverClockf(t, fakeClock, func() bool { return false; }, time.Second, 10*time.Millisecond, "error message %s", "formatted")
+func NeverClockf(t TestingT, clk clock.Clock, condition func() bool, waitFor time.Duration, tick time.Duration, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.NeverClockf(t, clk, condition, waitFor, tick, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
+// NeverContext asserts that the given condition is not met before ctx is
+// done, checking it tick after the end of each attempt. The condition
+// receives ctx, to abort its work when ctx is done. The assertion waits
+// for the running condition to return before reporting.
+//
+//	require.NeverContext(t, ctx, func(ctx context.Context) bool { return crashed(ctx) }, 10*time.Millisecond)
+func NeverContext(t TestingT, ctx context.Context, condition func(ctx context.Context) bool, tick time.Duration, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.NeverContext(t, ctx, condition, tick, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// NeverContextf asserts that the given condition is not met before ctx is
+// done, checking it tick after the end of each attempt. The condition
+// receives ctx, to abort its work when ctx is done. The assertion waits
+// for the running condition to return before reporting.
+//
+//	require.NeverContextf(t, ctx, func(ctx context.Context) bool { return crashed(ctx) }, 10*time.Millisecond, "error message %s", "formatted")
+func NeverContextf(t TestingT, ctx context.Context, condition func(ctx context.Context) bool, tick time.Duration, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.NeverContextf(t, ctx, condition, tick, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
 // Neverf asserts that the given condition doesn't satisfy in waitFor time,
 // periodically checking the target function each tick.
 //
@@ -1397,6 +1685,94 @@ func NoFileExistsf(t TestingT, path string, msg string, args ...interface{}) {
 	t.FailNow()
 }
 
+// NoGoroutineLeaks asserts that the goroutines started by calling f have
+// exited, waiting up to one second for them to do so.
+//
+//	require.NoGoroutineLeaks(t, func() { server.Shutdown(ctx) })
+func NoGoroutineLeaks(t TestingT, f func(), msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.NoGoroutineLeaks(t, f, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// NoGoroutineLeaksIgnoring asserts that the goroutines started by calling f
+// have exited, waiting up to one second for them to do so, like
+// NoGoroutineLeaks. Goroutines whose stack contains one of the ignore
+// strings, typically the name of the function they run, are not reported.
+//
+//	require.NoGoroutineLeaksIgnoring(t, func() { client.Get(url) }, []string{"net/http.(*persistConn).readLoop"})
+func NoGoroutineLeaksIgnoring(t TestingT, f func(), ignore []string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.NoGoroutineLeaksIgnoring(t, f, ignore, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// NoGoroutineLeaksIgnoringf asserts that the goroutines started by calling f
+// have exited, waiting up to one second for them to do so, like
+// NoGoroutineLeaks. Goroutines whose stack contains one of the ignore
+// strings, typically the name of the function they run, are not reported.
+//
+//	require.NoGoroutineLeaksIgnoringf(t, func() { client.Get(url) }, []string{"net/http.(*persistConn).readLoop"}, "error message %s", "formatted")
+func NoGoroutineLeaksIgnoringf(t TestingT, f func(), ignore []string, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.NoGoroutineLeaksIgnoringf(t, f, ignore, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
+// NoGoroutineLeaksf asserts that the goroutines started by calling f have
+// exited, waiting up to one second for them to do so.
+//
+//	require.NoGoroutineLeaksf(t, func() { server.Shutdown(ctx) }, "error message %s", "formatted")
+func NoGoroutineLeaksf(t TestingT, f func(), msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.NoGoroutineLeaksf(t, f, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
+// NoReceive asserts that no value is received from the specified channel,
+// and that it is not closed, for the given duration.
+//
+//	require.NoReceive(t, events, 100*time.Millisecond)
+func NoReceive(t TestingT, ch interface{}, duration time.Duration, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.NoReceive(t, ch, duration, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// NoReceivef asserts that no value is received from the specified channel,
+// and that it is not closed, for the given duration.
+//
+//	require.NoReceivef(t, events, 100*time.Millisecond, "error message %s", "formatted")
+func NoReceivef(t TestingT, ch interface{}, duration time.Duration, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.NoReceivef(t, ch, duration, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
 // NotContains asserts that the specified string, list(array, slice...) or map does NOT contain the
 // specified substring or element.
 //
@@ -1911,6 +2287,64 @@ func Positivef(t TestingT, e interface{}, msg string, args ...interface{}) {
 	t.FailNow()
 }
 
+// Receives asserts that a value is received from the specified channel
+// within timeout, and returns it.
+//
+//	require.Receives(t, results, time.Second)
+func Receives(t TestingT, ch interface{}, timeout time.Duration, msgAndArgs ...interface{}) interface{} {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	value, ok := assert.Receives(t, ch, timeout, msgAndArgs...)
+	if !ok {
+		t.FailNow()
+	}
+	return value
+}
+
+// ReceivesValue asserts that a value equal to expected is received from
+// the specified channel within timeout.
+//
+//	require.ReceivesValue(t, results, 42, time.Second)
+func ReceivesValue(t TestingT, ch interface{}, expected interface{}, timeout time.Duration, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.ReceivesValue(t, ch, expected, timeout, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// ReceivesValuef asserts that a value equal to expected is received from
+// the specified channel within timeout.
+//
+//	require.ReceivesValuef(t, results, 42, time.Second, "error message %s", "formatted")
+func ReceivesValuef(t TestingT, ch interface{}, expected interface{}, timeout time.Duration, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.ReceivesValuef(t, ch, expected, timeout, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
+// Receivesf asserts that a value is received from the specified channel
+// within timeout, and returns it.
+//
+//	require.Receivesf(t, results, time.Second, "error message %s", "formatted")
+func Receivesf(t TestingT, ch interface{}, timeout time.Duration, msg string, args ...interface{}) interface{} {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	value, ok := assert.Receivesf(t, ch, timeout, msg, args...)
+	if !ok {
+		t.FailNow()
+	}
+	return value
+}
+
 // Regexp asserts that a specified regexp matches a string.
 //
 //	require.Regexp(t, regexp.MustCompile("start"), "it's starting")
@@ -1939,6 +2373,204 @@ func Regexpf(t TestingT, rx interface{}, str interface{}, msg string, args ...in
 	t.FailNow()
 }
 
+// ResponseBody asserts that the body of the response recorded by ServeHTTP
+// is the specified string.
+//
+//	require.ResponseBody(t, resp, "pong")
+func ResponseBody(t TestingT, resp *assert.HTTPResponse, body string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.ResponseBody(t, resp, body, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// ResponseBodyContains asserts that the body of the response recorded by
+// ServeHTTP contains the specified substring.
+//
+//	require.ResponseBodyContains(t, resp, "Welcome")
+func ResponseBodyContains(t TestingT, resp *assert.HTTPResponse, substr string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.ResponseBodyContains(t, resp, substr, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// ResponseBodyContainsf asserts that the body of the response recorded by
+// ServeHTTP contains the specified substring.
+//
+//	require.ResponseBodyContainsf(t, resp, "Welcome", "error message %s", "formatted")
+func ResponseBodyContainsf(t TestingT, resp *assert.HTTPResponse, substr string, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.ResponseBodyContainsf(t, resp, substr, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
+// ResponseBodyf asserts that the body of the response recorded by ServeHTTP
+// is the specified string.
+//
+//	require.ResponseBodyf(t, resp, "pong", "error message %s", "formatted")
+func ResponseBodyf(t TestingT, resp *assert.HTTPResponse, body string, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.ResponseBodyf(t, resp, body, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
+// ResponseContentType asserts that the media type of the response
+// recorded by ServeHTTP is the specified one, ignoring parameters such as
+// the charset.
+//
+//	require.ResponseContentType(t, resp, "application/json")
+func ResponseContentType(t TestingT, resp *assert.HTTPResponse, mediaType string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.ResponseContentType(t, resp, mediaType, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// ResponseContentTypef asserts that the media type of the response
+// recorded by ServeHTTP is the specified one, ignoring parameters such as
+// the charset.
+//
+//	require.ResponseContentTypef(t, resp, "application/json", "error message %s", "formatted")
+func ResponseContentTypef(t TestingT, resp *assert.HTTPResponse, mediaType string, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.ResponseContentTypef(t, resp, mediaType, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
+// ResponseCookie asserts that the response recorded by ServeHTTP sets a
+// cookie with the specified name and value.
+//
+//	require.ResponseCookie(t, resp, "session", "abc123")
+func ResponseCookie(t TestingT, resp *assert.HTTPResponse, name string, value string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.ResponseCookie(t, resp, name, value, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// ResponseCookief asserts that the response recorded by ServeHTTP sets a
+// cookie with the specified name and value.
+//
+//	require.ResponseCookief(t, resp, "session", "abc123", "error message %s", "formatted")
+func ResponseCookief(t TestingT, resp *assert.HTTPResponse, name string, value string, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.ResponseCookief(t, resp, name, value, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
+// ResponseHeader asserts that the response recorded by ServeHTTP has a
+// header with the specified key and value.
+//
+//	require.ResponseHeader(t, resp, "Cache-Control", "no-store")
+func ResponseHeader(t TestingT, resp *assert.HTTPResponse, key string, value string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.ResponseHeader(t, resp, key, value, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// ResponseHeaderf asserts that the response recorded by ServeHTTP has a
+// header with the specified key and value.
+//
+//	require.ResponseHeaderf(t, resp, "Cache-Control", "no-store", "error message %s", "formatted")
+func ResponseHeaderf(t TestingT, resp *assert.HTTPResponse, key string, value string, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.ResponseHeaderf(t, resp, key, value, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
+// ResponseJSONEq asserts that the body of the response recorded by
+// ServeHTTP is JSON equivalent to the specified JSON string, as JSONEq.
+//
+//	require.ResponseJSONEq(t, resp, `{"hello": "world", "foo": "bar"}`)
+func ResponseJSONEq(t TestingT, resp *assert.HTTPResponse, expected string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.ResponseJSONEq(t, resp, expected, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// ResponseJSONEqf asserts that the body of the response recorded by
+// ServeHTTP is JSON equivalent to the specified JSON string, as JSONEq.
+//
+//	require.ResponseJSONEqf(t, resp, `{"hello": "world", "foo": "bar"}`, "error message %s", "formatted")
+func ResponseJSONEqf(t TestingT, resp *assert.HTTPResponse, expected string, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.ResponseJSONEqf(t, resp, expected, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
+// ResponseStatus asserts that the response recorded by ServeHTTP has the
+// specified status code.
+//
+//	require.ResponseStatus(t, resp, http.StatusOK)
+func ResponseStatus(t TestingT, resp *assert.HTTPResponse, statusCode int, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.ResponseStatus(t, resp, statusCode, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// ResponseStatusf asserts that the response recorded by ServeHTTP has the
+// specified status code.
+//
+//	require.ResponseStatusf(t, resp, http.StatusOK, "error message %s", "formatted")
+func ResponseStatusf(t TestingT, resp *assert.HTTPResponse, statusCode int, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.ResponseStatusf(t, resp, statusCode, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
 // Same asserts that two pointers reference the same object.
 //
 //	require.Same(t, ptr1, ptr2)
@@ -1971,6 +2603,34 @@ func Samef(t TestingT, expected interface{}, actual interface{}, msg string, arg
 	t.FailNow()
 }
 
+// Sends asserts that value can be sent on the specified channel within
+// timeout, and sends it.
+//
+//	require.Sends(t, requests, req, time.Second)
+func Sends(t TestingT, ch interface{}, value interface{}, timeout time.Duration, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.Sends(t, ch, value, timeout, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// Sendsf asserts that value can be sent on the specified channel within
+// timeout, and sends it.
+//
+//	require.Sendsf(t, requests, req, time.Second, "error message %s", "formatted")
+func Sendsf(t TestingT, ch interface{}, value interface{}, timeout time.Duration, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.Sendsf(t, ch, value, timeout, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
 // Subset asserts that the specified list(array, slice...) or map contains all
 // elements given in the specified subset list(array, slice...) or map.
 //
@@ -2040,6 +2700,34 @@ func WithinDuration(t TestingT, expected time.Time, actual time.Time, delta time
 	t.FailNow()
 }
 
+// WithinDurationClock asserts that actual is within duration delta of the
+// current time of clk.
+//
+//	require.WithinDurationClock(t, fakeClock, createdAt, time.Second)
+func WithinDurationClock(t TestingT, clk clock.Clock, actual time.Time, delta time.Duration, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.WithinDurationClock(t, clk, actual, delta, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// WithinDurationClockf asserts that actual is within duration delta of the
+// current time of clk.
+//
+//	require.WithinDurationClockf(t, fakeClock, createdAt, time.Second, "error message %s", "formatted")
+func WithinDurationClockf(t TestingT, clk clock.Clock, actual time.Time, delta time.Duration, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.WithinDurationClockf(t, clk, actual, delta, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
 // WithinDurationf asserts that the two times are within duration delta of each other.
 //
 //	require.WithinDurationf(t, time.Now(), time.Now(), 10*time.Second, "error message %s", "formatted")
@@ -3,12 +3,36 @@
 package require
 
 import (
+	context "context"
 	assert "github.com/stretchr/testify/assert"
+	clock "github.com/stretchr/testify/clock"
 	http "net/http"
 	url "net/url"
 	time "time"
 )
 
+// ChannelClosed asserts that the specified channel is closed, with no value
+// left to receive, within timeout.
+//
+//	a.ChannelClosed(done, time.Second)
+func (a *Assertions) ChannelClosed(ch interface{}, timeout time.Duration, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	ChannelClosed(a.t, ch, timeout, msgAndArgs...)
+}
+
+// ChannelClosedf asserts that the specified channel is closed, with no value
+// left to receive, within timeout.
+//
+//	a.ChannelClosedf(done, time.Second, "error message %s", "formatted")
+func (a *Assertions) ChannelClosedf(ch interface{}, timeout time.Duration, msg string, args ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	ChannelClosedf(a.t, ch, timeout, msg, args...)
+}
+
 // Condition uses a Comparison to assert a complex condition.
 func (a *Assertions) Condition(comp assert.Comparison, msgAndArgs ...interface{}) {
 	if h, ok := a.t.(tHelper); ok {
@@ -320,6 +344,82 @@ func (a *Assertions) Eventually(condition func() bool, waitFor time.Duration, ti
 	Eventually(a.t, condition, waitFor, tick, msgAndArgs...)
 }
 
+// EventuallyClock is like Eventually, but waits on clk instead of the real
+// clock, so that it can be driven by a clock.Fake.
+//
+//	a.EventuallyClock(fakeClock, func() bool { return true; }, time.Second, 10*time.Millisecond)
+func (a *Assertions) EventuallyClock(clk clock.Clock, condition func() bool, waitFor time.Duration, tick time.Duration, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	EventuallyClock(a.t, clk, condition, waitFor, tick, msgAndArgs...)
+}
+
+// EventuallyClockf is like Eventually, but waits on clk instead of the real
+// clock, so that it can be driven by a clock.Fake.
+//
+//	a.EventuallyClockf(fakeClock, func() bool { return true; }, time.Second, 10*time.Millisecond, "error message %s", "formatted")
+func (a *Assertions) EventuallyClockf(clk clock.Clock, condition func() bool, waitFor time.Duration, tick time.Duration, msg string, args ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	EventuallyClockf(a.t, clk, condition, waitFor, tick, msg, args...)
+}
+
+// EventuallyContext asserts that given condition will be met before ctx is
+// done, checking it tick after the end of each attempt. The condition
+// receives ctx, to abort its work when ctx is done. The assertion waits
+// for the running condition to return before reporting.
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+//	defer cancel()
+//	a.EventuallyContext(ctx, func(ctx context.Context) bool { return ping(ctx) == nil }, 10*time.Millisecond)
+func (a *Assertions) EventuallyContext(ctx context.Context, condition func(ctx context.Context) bool, tick time.Duration, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	EventuallyContext(a.t, ctx, condition, tick, msgAndArgs...)
+}
+
+// EventuallyContextf asserts that given condition will be met before ctx is
+// done, checking it tick after the end of each attempt. The condition
+// receives ctx, to abort its work when ctx is done. The assertion waits
+// for the running condition to return before reporting.
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+//	defer cancel()
+//	a.EventuallyContextf(ctx, func(ctx context.Context) bool { return ping(ctx) == nil }, 10*time.Millisecond, "error message %s", "formatted")
+func (a *Assertions) EventuallyContextf(ctx context.Context, condition func(ctx context.Context) bool, tick time.Duration, msg string, args ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	EventuallyContextf(a.t, ctx, condition, tick, msg, args...)
+}
+
+// EventuallyWithPolling asserts that given condition will be met in waitFor
+// time, checking it as configured by polling, for example with exponential
+// backoff.
+//
+//	a.EventuallyWithPolling(func() bool { return true; }, time.Second, assert.Polling{Tick: time.Millisecond, Backoff: 2, Immediate: true})
+func (a *Assertions) EventuallyWithPolling(condition func() bool, waitFor time.Duration, polling assert.Polling, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	EventuallyWithPolling(a.t, condition, waitFor, polling, msgAndArgs...)
+}
+
+// EventuallyWithPollingf asserts that given condition will be met in waitFor
+// time, checking it as configured by polling, for example with exponential
+// backoff.
+//
+//	a.EventuallyWithPollingf(func() bool { return true; }, time.Second, assert.Polling{Tick: time.Millisecond, Backoff: 2, Immediate: true}, "error message %s", "formatted")
+func (a *Assertions) EventuallyWithPollingf(condition func() bool, waitFor time.Duration, polling assert.Polling, msg string, args ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	EventuallyWithPollingf(a.t, condition, waitFor, polling, msg, args...)
+}
+
 // EventuallyWithT asserts that given condition will be met in waitFor time,
 // periodically checking target function each tick. In contrast to Eventually,
 // it supplies a CollectT to the condition function, so that the condition
@@ -345,6 +445,68 @@ func (a *Assertions) EventuallyWithT(condition func(collect *assert.CollectT), w
 	EventuallyWithT(a.t, condition, waitFor, tick, msgAndArgs...)
 }
 
+// EventuallyWithTClock is like EventuallyWithT, but waits on clk instead
+// of the real clock, so that it can be driven by a clock.Fake.
+//
+//	a.EventuallyWithTClock(fakeClock, func(c *assert.CollectT) {
+//		assert.True(c, externalValue, "expected 'externalValue' to be true")
+//	}, 10*time.Second, 1*time.Second)
+func (a *Assertions) EventuallyWithTClock(clk clock.Clock, condition func(collect *assert.CollectT), waitFor time.Duration, tick time.Duration, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	EventuallyWithTClock(a.t, clk, condition, waitFor, tick, msgAndArgs...)
+}
+
+// EventuallyWithTClockf is like EventuallyWithT, but waits on clk instead
+// of the real clock, so that it can be driven by a clock.Fake.
+//
+//	a.EventuallyWithTClockf(fakeClock, func(c *assert.CollectT, "error message %s", "formatted") {
+//		assert.True(c, externalValue, "expected 'externalValue' to be true")
+//	}, 10*time.Second, 1*time.Second)
+func (a *Assertions) EventuallyWithTClockf(clk clock.Clock, condition func(collect *assert.CollectT), waitFor time.Duration, tick time.Duration, msg string, args ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	EventuallyWithTClockf(a.t, clk, condition, waitFor, tick, msg, args...)
+}
+
+// EventuallyWithTContext is like EventuallyWithT, but checks the condition
+// until ctx is done instead of for a fixed duration. The condition
+// receives ctx, to abort its work when ctx is done. The assertion waits
+// for the running condition to return before reporting.
+//
+//	a.EventuallyWithTContext(ctx, func(ctx context.Context, c *assert.CollectT) {
+//		resp, err := client.Get(ctx, key)
+//		if assert.NoError(c, err) {
+//			assert.Equal(c, "value", resp)
+//		}
+//	}, 10*time.Millisecond)
+func (a *Assertions) EventuallyWithTContext(ctx context.Context, condition func(ctx context.Context, collect *assert.CollectT), tick time.Duration, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	EventuallyWithTContext(a.t, ctx, condition, tick, msgAndArgs...)
+}
+
+// EventuallyWithTContextf is like EventuallyWithT, but checks the condition
+// until ctx is done instead of for a fixed duration. The condition
+// receives ctx, to abort its work when ctx is done. The assertion waits
+// for the running condition to return before reporting.
+//
+//	a.EventuallyWithTContextf(ctx, func(ctx context.Context, c *assert.CollectT, "error message %s", "formatted") {
+//		resp, err := client.Get(ctx, key)
+//		if assert.NoError(c, err) {
+//			assert.Equal(c, "value", resp)
+//		}
+//	}, 10*time.Millisecond)
+func (a *Assertions) EventuallyWithTContextf(ctx context.Context, condition func(ctx context.Context, collect *assert.CollectT), tick time.Duration, msg string, args ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	EventuallyWithTContextf(a.t, ctx, condition, tick, msg, args...)
+}
+
 // EventuallyWithTf asserts that given condition will be met in waitFor time,
 // periodically checking target function each tick. In contrast to Eventually,
 // it supplies a CollectT to the condition function, so that the condition
@@ -370,6 +532,30 @@ func (a *Assertions) EventuallyWithTf(condition func(collect *assert.CollectT),
 	EventuallyWithTf(a.t, condition, waitFor, tick, msg, args...)
 }
 
+// EventuallyWithValue asserts that given condition will be met in waitFor
+// time, checking it as configured by polling. The condition also returns
+// the value it observed, which is reported if the condition is never met.
+//
+//	a.EventuallyWithValue(func() (bool, interface{}) { n := queue.Len(); return n == 0, n }, time.Second, assert.Polling{Tick: 10*time.Millisecond})
+func (a *Assertions) EventuallyWithValue(condition func() (bool, interface{}), waitFor time.Duration, polling assert.Polling, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	EventuallyWithValue(a.t, condition, waitFor, polling, msgAndArgs...)
+}
+
+// EventuallyWithValuef asserts that given condition will be met in waitFor
+// time, checking it as configured by polling. The condition also returns
+// the value it observed, which is reported if the condition is never met.
+//
+//	a.EventuallyWithValuef(func() (bool, interface{}) { n := queue.Len(); return n == 0, n }, time.Second, assert.Polling{Tick: 10*time.Millisecond}, "error message %s", "formatted")
+func (a *Assertions) EventuallyWithValuef(condition func() (bool, interface{}), waitFor time.Duration, polling assert.Polling, msg string, args ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	EventuallyWithValuef(a.t, condition, waitFor, polling, msg, args...)
+}
+
 // Eventuallyf asserts that given condition will be met in waitFor time,
 // periodically checking target function each tick.
 //
@@ -1010,6 +1196,54 @@ func (a *Assertions) Never(condition func() bool, waitFor time.Duration, tick ti
 	Never(a.t, condition, waitFor, tick, msgAndArgs...)
 }
 
+// NeverClock is like Never, but waits on clk instead of the real clock, so
+// that it can be driven by a clock.Fake.
+//
+//	a.NeverClock(fakeClock, func() bool { return false; }, time.Second, 10*time.Millisecond)
+func (a *Assertions) NeverClock(clk clock.Clock, condition func() bool, waitFor time.Duration, tick time.Duration, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	NeverClock(a.t, clk, condition, waitFor, tick, msgAndArgs...)
+}
+
+// NeverClockf is like Never, but waits on clk instead of the real clock, so
+// that it can be driven by a clock.Fake.
+//
+//	a.NeverClockf(fakeClock, func() bool { return false; }, time.Second, 10*time.Millisecond, "error message %s", "formatted")
+func (a *Assertions) NeverClockf(clk clock.Clock, condition func() bool, waitFor time.Duration, tick time.Duration, msg string, args ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	NeverClockf(a.t, clk, condition, waitFor, tick, msg, args...)
+}
+
+// NeverContext asserts that the given condition is not met before ctx is
+// done, checking it tick after the end of each attempt. The condition
+// receives ctx, to abort its work when ctx is done. The assertion waits
+// for the running condition to return before reporting.
+//
+//	a.NeverContext(ctx, func(ctx context.Context) bool { return crashed(ctx) }, 10*time.Millisecond)
+func (a *Assertions) NeverContext(ctx context.Context, condition func(ctx context.Context) bool, tick time.Duration, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	NeverContext(a.t, ctx, condition, tick, msgAndArgs...)
+}
+
+// NeverContextf asserts that the given condition is not met before ctx is
+// done, checking it tick after the end of each attempt. The condition
+// receives ctx, to abort its work when ctx is done. The assertion waits
+// for the running condition to return before reporting.
+//
+//	a.NeverContextf(ctx, func(ctx context.Context) bool { return crashed(ctx) }, 10*time.Millisecond, "error message %s", "formatted")
+func (a *Assertions) NeverContextf(ctx context.Context, condition func(ctx context.Context) bool, tick time.Duration, msg string, args ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	NeverContextf(a.t, ctx, condition, tick, msg, args...)
+}
+
 // Neverf asserts that the given condition doesn't satisfy in waitFor time,
 // periodically checking the target function each tick.
 //
@@ -1103,6 +1337,76 @@ func (a *Assertions) NoFileExistsf(path string, msg string, args ...interface{})
 	NoFileExistsf(a.t, path, msg, args...)
 }
 
+// NoGoroutineLeaks asserts that the goroutines started by calling f have
+// exited, waiting up to one second for them to do so.
+//
+//	a.NoGoroutineLeaks(func() { server.Shutdown(ctx) })
+func (a *Assertions) NoGoroutineLeaks(f func(), msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	NoGoroutineLeaks(a.t, f, msgAndArgs...)
+}
+
+// NoGoroutineLeaksIgnoring asserts that the goroutines started by calling f
+// have exited, waiting up to one second for them to do so, like
+// NoGoroutineLeaks. Goroutines whose stack contains one of the ignore
+// strings, typically the name of the function they run, are not reported.
+//
+//	a.NoGoroutineLeaksIgnoring(func() { client.Get(url) }, []string{"net/http.(*persistConn).readLoop"})
+func (a *Assertions) NoGoroutineLeaksIgnoring(f func(), ignore []string, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	NoGoroutineLeaksIgnoring(a.t, f, ignore, msgAndArgs...)
+}
+
+// NoGoroutineLeaksIgnoringf asserts that the goroutines started by calling f
+// have exited, waiting up to one second for them to do so, like
+// NoGoroutineLeaks. Goroutines whose stack contains one of the ignore
+// strings, typically the name of the function they run, are not reported.
+//
+//	a.NoGoroutineLeaksIgnoringf(func() { client.Get(url) }, []string{"net/http.(*persistConn).readLoop"}, "error message %s", "formatted")
+func (a *Assertions) NoGoroutineLeaksIgnoringf(f func(), ignore []string, msg string, args ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	NoGoroutineLeaksIgnoringf(a.t, f, ignore, msg, args...)
+}
+
+// NoGoroutineLeaksf asserts that the goroutines started by calling f have
+// exited, waiting up to one second for them to do so.
+//
+//	a.NoGoroutineLeaksf(func() { server.Shutdown(ctx) }, "error message %s", "formatted")
+func (a *Assertions) NoGoroutineLeaksf(f func(), msg string, args ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	NoGoroutineLeaksf(a.t, f, msg, args...)
+}
+
+// NoReceive asserts that no value is received from the specified channel,
+// and that it is not closed, for the given duration.
+//
+//	a.NoReceive(events, 100*time.Millisecond)
+func (a *Assertions) NoReceive(ch interface{}, duration time.Duration, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	NoReceive(a.t, ch, duration, msgAndArgs...)
+}
+
+// NoReceivef asserts that no value is received from the specified channel,
+// and that it is not closed, for the given duration.
+//
+//	a.NoReceivef(events, 100*time.Millisecond, "error message %s", "formatted")
+func (a *Assertions) NoReceivef(ch interface{}, duration time.Duration, msg string, args ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	NoReceivef(a.t, ch, duration, msg, args...)
+}
+
 // NotContains asserts that the specified string, list(array, slice...) or map does NOT contain the
 // specified substring or element.
 //
@@ -1509,6 +1813,50 @@ func (a *Assertions) Positivef(e interface{}, msg string, args ...interface{}) {
 	Positivef(a.t, e, msg, args...)
 }
 
+// Receives asserts that a value is received from the specified channel
+// within timeout, and returns it.
+//
+//	a.Receives(results, time.Second)
+func (a *Assertions) Receives(ch interface{}, timeout time.Duration, msgAndArgs ...interface{}) interface{} {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return Receives(a.t, ch, timeout, msgAndArgs...)
+}
+
+// ReceivesValue asserts that a value equal to expected is received from
+// the specified channel within timeout.
+//
+//	a.ReceivesValue(results, 42, time.Second)
+func (a *Assertions) ReceivesValue(ch interface{}, expected interface{}, timeout time.Duration, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	ReceivesValue(a.t, ch, expected, timeout, msgAndArgs...)
+}
+
+// ReceivesValuef asserts that a value equal to expected is received from
+// the specified channel within timeout.
+//
+//	a.ReceivesValuef(results, 42, time.Second, "error message %s", "formatted")
+func (a *Assertions) ReceivesValuef(ch interface{}, expected interface{}, timeout time.Duration, msg string, args ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	ReceivesValuef(a.t, ch, expected, timeout, msg, args...)
+}
+
+// Receivesf asserts that a value is received from the specified channel
+// within timeout, and returns it.
+//
+//	a.Receivesf(results, time.Second, "error message %s", "formatted")
+func (a *Assertions) Receivesf(ch interface{}, timeout time.Duration, msg string, args ...interface{}) interface{} {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return Receivesf(a.t, ch, timeout, msg, args...)
+}
+
 // Regexp asserts that a specified regexp matches a string.
 //
 //	a.Regexp(regexp.MustCompile("start"), "it's starting")
@@ -1531,6 +1879,162 @@ func (a *Assertions) Regexpf(rx interface{}, str interface{}, msg string, args .
 	Regexpf(a.t, rx, str, msg, args...)
 }
 
+// ResponseBody asserts that the body of the response recorded by ServeHTTP
+// is the specified string.
+//
+//	a.ResponseBody(resp, "pong")
+func (a *Assertions) ResponseBody(resp *assert.HTTPResponse, body string, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	ResponseBody(a.t, resp, body, msgAndArgs...)
+}
+
+// ResponseBodyContains asserts that the body of the response recorded by
+// ServeHTTP contains the specified substring.
+//
+//	a.ResponseBodyContains(resp, "Welcome")
+func (a *Assertions) ResponseBodyContains(resp *assert.HTTPResponse, substr string, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	ResponseBodyContains(a.t, resp, substr, msgAndArgs...)
+}
+
+// ResponseBodyContainsf asserts that the body of the response recorded by
+// ServeHTTP contains the specified substring.
+//
+//	a.ResponseBodyContainsf(resp, "Welcome", "error message %s", "formatted")
+func (a *Assertions) ResponseBodyContainsf(resp *assert.HTTPResponse, substr string, msg string, args ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	ResponseBodyContainsf(a.t, resp, substr, msg, args...)
+}
+
+// ResponseBodyf asserts that the body of the response recorded by ServeHTTP
+// is the specified string.
+//
+//	a.ResponseBodyf(resp, "pong", "error message %s", "formatted")
+func (a *Assertions) ResponseBodyf(resp *assert.HTTPResponse, body string, msg string, args ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	ResponseBodyf(a.t, resp, body, msg, args...)
+}
+
+// ResponseContentType asserts that the media type of the response
+// recorded by ServeHTTP is the specified one, ignoring parameters such as
+// the charset.
+//
+//	a.ResponseContentType(resp, "application/json")
+func (a *Assertions) ResponseContentType(resp *assert.HTTPResponse, mediaType string, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	ResponseContentType(a.t, resp, mediaType, msgAndArgs...)
+}
+
+// ResponseContentTypef asserts that the media type of the response
+// recorded by ServeHTTP is the specified one, ignoring parameters such as
+// the charset.
+//
+//	a.ResponseContentTypef(resp, "application/json", "error message %s", "formatted")
+func (a *Assertions) ResponseContentTypef(resp *assert.HTTPResponse, mediaType string, msg string, args ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	ResponseContentTypef(a.t, resp, mediaType, msg, args...)
+}
+
+// ResponseCookie asserts that the response recorded by ServeHTTP sets a
+// cookie with the specified name and value.
+//
+//	a.ResponseCookie(resp, "session", "abc123")
+func (a *Assertions) ResponseCookie(resp *assert.HTTPResponse, name string, value string, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	ResponseCookie(a.t, resp, name, value, msgAndArgs...)
+}
+
+// ResponseCookief asserts that the response recorded by ServeHTTP sets a
+// cookie with the specified name and value.
+//
+//	a.ResponseCookief(resp, "session", "abc123", "error message %s", "formatted")
+func (a *Assertions) ResponseCookief(resp *assert.HTTPResponse, name string, value string, msg string, args ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	ResponseCookief(a.t, resp, name, value, msg, args...)
+}
+
+// ResponseHeader asserts that the response recorded by ServeHTTP has a
+// header with the specified key and value.
+//
+//	a.ResponseHeader(resp, "Cache-Control", "no-store")
+func (a *Assertions) ResponseHeader(resp *assert.HTTPResponse, key string, value string, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	ResponseHeader(a.t, resp, key, value, msgAndArgs...)
+}
+
+// ResponseHeaderf asserts that the response recorded by ServeHTTP has a
+// header with the specified key and value.
+//
+//	a.ResponseHeaderf(resp, "Cache-Control", "no-store", "error message %s", "formatted")
+func (a *Assertions) ResponseHeaderf(resp *assert.HTTPResponse, key string, value string, msg string, args ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	ResponseHeaderf(a.t, resp, key, value, msg, args...)
+}
+
+// ResponseJSONEq asserts that the body of the response recorded by
+// ServeHTTP is JSON equivalent to the specified JSON string, as JSONEq.
+//
+//	a.ResponseJSONEq(resp, `{"hello": "world", "foo": "bar"}`)
+func (a *Assertions) ResponseJSONEq(resp *assert.HTTPResponse, expected string, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	ResponseJSONEq(a.t, resp, expected, msgAndArgs...)
+}
+
+// ResponseJSONEqf asserts that the body of the response recorded by
+// ServeHTTP is JSON equivalent to the specified JSON string, as JSONEq.
+//
+//	a.ResponseJSONEqf(resp, `{"hello": "world", "foo": "bar"}`, "error message %s", "formatted")
+func (a *Assertions) ResponseJSONEqf(resp *assert.HTTPResponse, expected string, msg string, args ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	ResponseJSONEqf(a.t, resp, expected, msg, args...)
+}
+
+// ResponseStatus asserts that the response recorded by ServeHTTP has the
+// specified status code.
+//
+//	a.ResponseStatus(resp, http.StatusOK)
+func (a *Assertions) ResponseStatus(resp *assert.HTTPResponse, statusCode int, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	ResponseStatus(a.t, resp, statusCode, msgAndArgs...)
+}
+
+// ResponseStatusf asserts that the response recorded by ServeHTTP has the
+// specified status code.
+//
+//	a.ResponseStatusf(resp, http.StatusOK, "error message %s", "formatted")
+func (a *Assertions) ResponseStatusf(resp *assert.HTTPResponse, statusCode int, msg string, args ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	ResponseStatusf(a.t, resp, statusCode, msg, args...)
+}
+
 // Same asserts that two pointers reference the same object.
 //
 //	a.Same(ptr1, ptr2)
@@ -1557,6 +2061,28 @@ func (a *Assertions) Samef(expected interface{}, actual interface{}, msg string,
 	Samef(a.t, expected, actual, msg, args...)
 }
 
+// Sends asserts that value can be sent on the specified channel within
+// timeout, and sends it.
+//
+//	a.Sends(requests, req, time.Second)
+func (a *Assertions) Sends(ch interface{}, value interface{}, timeout time.Duration, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	Sends(a.t, ch, value, timeout, msgAndArgs...)
+}
+
+// Sendsf asserts that value can be sent on the specified channel within
+// timeout, and sends it.
+//
+//	a.Sendsf(requests, req, time.Second, "error message %s", "formatted")
+func (a *Assertions) Sendsf(ch interface{}, value interface{}, timeout time.Duration, msg string, args ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	Sendsf(a.t, ch, value, timeout, msg, args...)
+}
+
 // Subset asserts that the specified list(array, slice...) or map contains all
 // elements given in the specified subset list(array, slice...) or map.
 //
@@ -1611,6 +2137,28 @@ func (a *Assertions) WithinDuration(expected time.Time, actual time.Time, delta
 	WithinDuration(a.t, expected, actual, delta, msgAndArgs...)
 }
 
+// WithinDurationClock asserts that actual is within duration delta of the
+// current time of clk.
+//
+//	a.WithinDurationClock(fakeClock, createdAt, time.Second)
+func (a *Assertions) WithinDurationClock(clk clock.Clock, actual time.Time, delta time.Duration, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	WithinDurationClock(a.t, clk, actual, delta, msgAndArgs...)
+}
+
+// WithinDurationClockf asserts that actual is within duration delta of the
+// current time of clk.
+//
+//	a.WithinDurationClockf(fakeClock, createdAt, time.Second, "error message %s", "formatted")
+func (a *Assertions) WithinDurationClockf(clk clock.Clock, actual time.Time, delta time.Duration, msg string, args ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	WithinDurationClockf(a.t, clk, actual, delta, msg, args...)
+}
+
 // WithinDurationf asserts that the two times are within duration delta of each other.
 //
 //	a.WithinDurationf(time.Now(), time.Now(), 10*time.Second, "error message %s", "formatted")
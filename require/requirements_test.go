@@ -710,3 +710,16 @@ func TestEventuallyWithTTrue(t *testing.T) {
 	False(t, mockT.Failed, "Check should pass")
 	Equal(t, 2, counter, "Condition is expected to be called 2 times")
 }
+
+func TestReceives(t *testing.T) {
+	ch := make(chan int, 1)
+	ch <- 42
+	Equal(t, 42, Receives(t, ch, time.Second))
+
+	mockT := new(MockT)
+	Nil(t, Receives(mockT, ch, 10*time.Millisecond))
+	True(t, mockT.Failed, "Check should fail")
+
+	ch <- 43
+	Equal(t, 43, New(t).Receives(ch, time.Second))
+}
@@ -0,0 +1,121 @@
+package suite
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+)
+
+var testingBType = reflect.TypeOf((*testing.B)(nil))
+
+// RunBenchmarks takes a testing suite and runs all of the benchmarks
+// attached to it, as sub-benchmarks of b. Benchmarks are the methods of
+// the suite whose name starts with "Benchmark" and which take either no
+// argument or a *testing.B.
+//
+// SetupSuite and TearDownSuite run once around all the benchmarks, and
+// SetupBenchmark and TearDownBenchmark around each run of a benchmark,
+// outside of its timing. During the benchmarks, the suite's B method
+// returns the current *testing.B and its assertions report to it.
+func RunBenchmarks(b *testing.B, suite BenchmarkingSuite) {
+	defer recoverAndFailOnPanic(b)
+
+	suite.SetB(b)
+
+	suiteCtx, cancelSuite := context.WithCancel(context.Background())
+	defer cancelSuite()
+	setContext(suite, suiteCtx)
+
+	hooks := newSuiteHooks(suite)
+
+	fixtures := newFixtures(func() testing.TB { return suite.B() })
+	setFixtures(suite, fixtures)
+	for _, hook := range hooks.setup((*WithFixtures)(nil)) {
+		hook.(WithFixtures).RegisterFixtures(fixtures)
+	}
+	fixtures.begin(b, SuiteScope, nil)
+	defer fixtures.end(b)
+
+	suiteB := b
+	benchmarks := []testing.InternalBenchmark{}
+	methodFinder := reflect.TypeOf(suite)
+
+	for i := 0; i < methodFinder.NumMethod(); i++ {
+		method := methodFinder.Method(i)
+
+		ok, err := prefixedMethodFilter("Benchmark", method.Name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "testify: invalid regexp for -m: %s\n", err)
+			os.Exit(1)
+		}
+
+		if !ok {
+			continue
+		}
+
+		if err := checkMethodSignature(method, "benchmark", "a *testing.B", testingBType); err != nil {
+			benchmarks = append(benchmarks, testing.InternalBenchmark{
+				Name: method.Name,
+				F: func(b *testing.B) {
+					b.Fatal(err)
+				},
+			})
+			continue
+		}
+
+		benchmark := testing.InternalBenchmark{
+			Name: method.Name,
+			F: func(b *testing.B) {
+				parentB := suite.B()
+				suite.SetB(b)
+
+				fixtures.begin(b, TestScope, suiteB)
+				defer fixtures.end(b)
+
+				defer recoverAndFailOnPanic(b)
+				defer func() {
+					b.StopTimer()
+
+					for _, hook := range hooks.teardown((*TearDownBenchmarkSuite)(nil)) {
+						hook.(TearDownBenchmarkSuite).TearDownBenchmark()
+					}
+
+					suite.SetB(parentB)
+				}()
+
+				for _, hook := range hooks.setup((*SetupBenchmarkSuite)(nil)) {
+					hook.(SetupBenchmarkSuite).SetupBenchmark()
+				}
+
+				args := []reflect.Value{reflect.ValueOf(suite)}
+				if method.Type.NumIn() == 2 {
+					args = append(args, reflect.ValueOf(b))
+				}
+
+				b.ResetTimer()
+				method.Func.Call(args)
+			},
+		}
+		benchmarks = append(benchmarks, benchmark)
+	}
+
+	if len(benchmarks) == 0 {
+		b.Log("warning: no benchmarks to run")
+		return
+	}
+
+	for _, hook := range hooks.setup((*SetupAllSuite)(nil)) {
+		hook.(SetupAllSuite).SetupSuite()
+	}
+	defer func() {
+		for _, hook := range hooks.teardown((*TearDownAllSuite)(nil)) {
+			hook.(TearDownAllSuite).TearDownSuite()
+		}
+	}()
+
+	for _, benchmark := range benchmarks {
+		b.Run(benchmark.Name, benchmark.F)
+	}
+}
@@ -0,0 +1,112 @@
+package suite
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type benchmarkSuite struct {
+	Suite
+	setupSuiteCount        int
+	tearDownSuiteCount     int
+	setupBenchmarkCount    int
+	tearDownBenchmarkCount int
+	fixtureSetupCount      int
+	benchmarkB             map[string]*testing.B
+	testCount              int
+}
+
+func (s *benchmarkSuite) RegisterFixtures(fixtures *Fixtures) {
+	fixtures.Register("data", Fixture{
+		Scope: SuiteScope,
+		Setup: func(*Fixtures) (interface{}, error) {
+			s.fixtureSetupCount++
+			return []int{1, 2, 3}, nil
+		},
+	})
+}
+
+func (s *benchmarkSuite) SetupSuite() {
+	s.setupSuiteCount++
+}
+
+func (s *benchmarkSuite) TearDownSuite() {
+	s.tearDownSuiteCount++
+}
+
+func (s *benchmarkSuite) SetupBenchmark() {
+	s.setupBenchmarkCount++
+}
+
+func (s *benchmarkSuite) TearDownBenchmark() {
+	s.tearDownBenchmarkCount++
+}
+
+func (s *benchmarkSuite) BenchmarkSuiteB() {
+	s.benchmarkB["BenchmarkSuiteB"] = s.B()
+	data := s.Fixtures().Get("data").([]int)
+	for i := 0; i < s.B().N; i++ {
+		s.Len(data, 3)
+	}
+}
+
+func (s *benchmarkSuite) BenchmarkWithB(b *testing.B) {
+	s.benchmarkB["BenchmarkWithB"] = b
+	s.Equal(s.B(), b)
+	for i := 0; i < b.N; i++ {
+		_ = s.Fixtures().Get("data")
+	}
+}
+
+func (s *benchmarkSuite) TestNotABenchmark() {
+	s.testCount++
+}
+
+func TestRunBenchmarks(t *testing.T) {
+	// Keep the benchmarks short.
+	benchtime := flag.Lookup("test.benchtime").Value
+	defer benchtime.Set(benchtime.String())
+	require.NoError(t, benchtime.Set("10x"))
+
+	suite := &benchmarkSuite{benchmarkB: make(map[string]*testing.B)}
+	var parentB *testing.B
+	testing.Benchmark(func(b *testing.B) {
+		parentB = b
+		RunBenchmarks(b, suite)
+	})
+
+	assert.Equal(t, 1, suite.setupSuiteCount)
+	assert.Equal(t, 1, suite.tearDownSuiteCount)
+	assert.Equal(t, 1, suite.fixtureSetupCount)
+	assert.Equal(t, 0, suite.testCount)
+
+	// Each benchmark runs at least twice: once with b.N = 1 and once
+	// with enough iterations to be timed.
+	assert.GreaterOrEqual(t, suite.setupBenchmarkCount, 4)
+	assert.Equal(t, suite.setupBenchmarkCount, suite.tearDownBenchmarkCount)
+
+	assert.NotNil(t, suite.benchmarkB["BenchmarkSuiteB"])
+	assert.NotNil(t, suite.benchmarkB["BenchmarkWithB"])
+	assert.NotEqual(t, parentB, suite.benchmarkB["BenchmarkSuiteB"])
+	assert.Equal(t, parentB, suite.B())
+}
+
+type invalidBenchmarkSuite struct {
+	Suite
+}
+
+func (s *invalidBenchmarkSuite) BenchmarkWithT(*testing.T) {
+	panic("must not be called")
+}
+
+func TestRunBenchmarksInvalidSignature(t *testing.T) {
+	var parentB *testing.B
+	testing.Benchmark(func(b *testing.B) {
+		parentB = b
+		RunBenchmarks(b, new(invalidBenchmarkSuite))
+	})
+	assert.True(t, parentB.Failed(), "benchmark should fail")
+}
@@ -0,0 +1,73 @@
+package suite
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type suiteNameKey struct{}
+
+type testNameKey struct{}
+
+// SuiteNameFromContext returns the name of the suite that ctx, as returned
+// by Suite.Context, belongs to. It returns an empty string if ctx does not
+// come from a suite.
+func SuiteNameFromContext(ctx context.Context) string {
+	name, _ := ctx.Value(suiteNameKey{}).(string)
+	return name
+}
+
+// TestNameFromContext returns the full name of the test or subtest that
+// ctx, as returned by Suite.Context, is scoped to. It returns an empty
+// string if ctx does not come from a suite.
+func TestNameFromContext(ctx context.Context) string {
+	name, _ := ctx.Value(testNameKey{}).(string)
+	return name
+}
+
+// newTestContext returns a context derived from parent that carries the
+// name of t, and of the suite if suiteName is not empty. Its deadline is
+// the earliest of the deadline of t and timeout, if timeout is not 0. The
+// timeout only ends the context once the returned cancel function is
+// called, which callWithTimeout does after capturing the stack of the test.
+func newTestContext(parent context.Context, t *testing.T, suiteName string, timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx := parent
+	if suiteName != "" {
+		ctx = context.WithValue(ctx, suiteNameKey{}, suiteName)
+	}
+	ctx = context.WithValue(ctx, testNameKey{}, t.Name())
+
+	deadline, ok := t.Deadline()
+	var cancel context.CancelFunc
+	if ok {
+		ctx, cancel = context.WithDeadline(ctx, deadline)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	if timeout > 0 {
+		if timeoutDeadline := time.Now().Add(timeout); !ok || timeoutDeadline.Before(deadline) {
+			ctx = timeoutContext{Context: ctx, deadline: timeoutDeadline}
+		}
+	}
+	return ctx, cancel
+}
+
+// timeoutContext reports the deadline of a test with a timeout without
+// ending when it expires.
+type timeoutContext struct {
+	context.Context
+	deadline time.Time
+}
+
+func (c timeoutContext) Deadline() (time.Time, bool) {
+	return c.deadline, true
+}
+
+func (c timeoutContext) Err() error {
+	err := c.Context.Err()
+	if err != nil && !time.Now().Before(c.deadline) {
+		return context.DeadlineExceeded
+	}
+	return err
+}
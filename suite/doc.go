@@ -5,7 +5,15 @@
 // or individual tests (depending on which interface(s) you
 // implement).
 //
-// The suite package does not support parallel tests. See [issue 934].
+// Test methods taking a *testing.T or a context.Context may call
+// t.Parallel, and a suite implementing WithParallel has Run do so for any
+// of its tests. TearDownSuite then runs once all of them have ended.
+// Parallel tests share the suite: they must use their own *testing.T or
+// context.Context rather than T and Context, Fixtures.GetFor rather than
+// Fixtures.Get, and the hooks and fields of the suite must be safe for
+// concurrent use. WithLeakCheck cannot tell the goroutines of a test from
+// the ones of the tests running in parallel with it, so it fails the tests
+// running in parallel. See [issue 934].
 //
 // A testing suite is usually built by first extending the built-in
 // suite functionality from suite.Suite in testify.  Alternatively,
@@ -16,6 +24,13 @@
 // After that, you can implement any of the interfaces in
 // suite/interfaces.go to add setup/teardown functionality to your
 // suite, and add any methods that start with "Test" to add tests.
+// Test methods take either no argument, the *testing.T of the test or
+// the context.Context of the test, and return nothing.
+// Implement WithEmbeddedHooks to have the hooks of structs embedded in the
+// suite, such as a base suite setting up a database, called too: setup
+// hooks of embedded structs before the ones of the suite, and teardown
+// hooks after them. Implement WithLeakCheck to fail the tests that leave
+// goroutines running after TearDownTest.
 // Methods that do not match any suite interfaces and do not begin
 // with "Test" will not be run by testify, and can safely be used as
 // helper methods.
@@ -25,9 +40,18 @@
 // identity that "go test" is already looking for (i.e.
 // func(*testing.T)).
 //
+// Methods that begin with "Benchmark" are run as benchmarks by
+// suite.RunBenchmarks, called from a func(*testing.B) benchmark function.
+// They share SetupSuite and TearDownSuite with the tests of the suite.
+// Similarly, suite.RunFuzz runs a method of the suite as the target of a
+// func(*testing.F) fuzz function.
+//
 // Regular expression to select test suites specified command-line
 // argument "-run". Regular expression to select the methods
 // of test suites specified command-line argument "-m".
+// The methods of test suites run in alphabetical order unless the
+// command-line argument "-testify.shuffle" is set to "on" or to a seed,
+// which works like "-shuffle" of "go test".
 // Suite object has assertion methods.
 //
 // A crude example:
@@ -0,0 +1,306 @@
+package suite
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Scope is the lifetime of the value of a Fixture.
+type Scope int
+
+const (
+	// SuiteScope fixtures are built at most once per suite and torn down
+	// after TearDownSuite.
+	SuiteScope Scope = iota
+	// TestScope fixtures are built at most once per test and torn down
+	// after TearDownTest. In benchmarks run by RunBenchmarks, they are
+	// built at most once per benchmark and torn down after
+	// TearDownBenchmark.
+	TestScope
+	// SubTestScope fixtures are built at most once per subtest started
+	// with Suite.Run and torn down after TearDownSubTest.
+	SubTestScope
+)
+
+func (s Scope) String() string {
+	switch s {
+	case SuiteScope:
+		return "suite"
+	case TestScope:
+		return "test"
+	case SubTestScope:
+		return "subtest"
+	}
+	return fmt.Sprintf("Scope(%d)", int(s))
+}
+
+// Fixture describes how to build and release a value shared by the tests
+// of a suite, such as a database connection or an HTTP server.
+type Fixture struct {
+	// Scope is the lifetime of the value. A fixture can only depend on
+	// fixtures with the same or a wider scope.
+	Scope Scope
+
+	// Deps lists the names of the fixtures built before this one. Setup
+	// may also depend on other fixtures by calling Fixtures.Get.
+	Deps []string
+
+	// Setup builds the value of the fixture. It is called the first time
+	// the fixture is used in its scope.
+	Setup func(fixtures *Fixtures) (interface{}, error)
+
+	// Teardown, if not nil, releases the value built by Setup. Fixtures
+	// are torn down in the reverse order of their construction.
+	Teardown func(value interface{}) error
+}
+
+// Fixtures is a registry of the fixtures of a suite. Fixtures are
+// registered with Register, typically from the RegisterFixtures method of
+// a suite implementing WithFixtures, and built lazily by Get, or by GetFor
+// in tests running in parallel.
+type Fixtures struct {
+	*fixtureRegistry
+
+	// tb is the test the fixtures are built for. If nil, it is the current
+	// test of the suite.
+	tb testing.TB
+	// building lists the fixtures being built by the Setup function this
+	// Fixtures is passed to, outermost first.
+	building []string
+}
+
+// fixtureRegistry holds the fixtures of a suite and their values.
+type fixtureRegistry struct {
+	// current returns the current test or benchmark of the suite.
+	current func() testing.TB
+
+	mu       sync.Mutex
+	fixtures map[string]Fixture
+	// frames are the open suite, tests and subtests, by test.
+	frames map[testing.TB]*fixtureFrame
+	// tests is the number of open frames of TestScope.
+	tests int
+	// parallel is set once tests of the suite ran in parallel.
+	parallel bool
+}
+
+// fixtureFrame holds the fixtures built in a suite, test or subtest.
+type fixtureFrame struct {
+	scope  Scope
+	parent *fixtureFrame
+	values map[string]interface{}
+	order  []string
+	// building holds the fixtures being built, whose channel is closed
+	// once they are.
+	building map[string]chan struct{}
+}
+
+func newFixtures(current func() testing.TB) *Fixtures {
+	return &Fixtures{fixtureRegistry: &fixtureRegistry{
+		current:  current,
+		fixtures: make(map[string]Fixture),
+		frames:   make(map[testing.TB]*fixtureFrame),
+	}}
+}
+
+// Register declares the fixture with the given name. It panics if a
+// fixture with the same name is already registered.
+func (f *Fixtures) Register(name string, fixture Fixture) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.fixtures[name]; ok {
+		panic(fmt.Sprintf("suite: fixture %q registered twice", name))
+	}
+	if fixture.Setup == nil {
+		panic(fmt.Sprintf("suite: fixture %q has no Setup function", name))
+	}
+	f.fixtures[name] = fixture
+}
+
+// Get returns the value of the named fixture, building it and its
+// dependencies if they have not been built yet in their scope. It fails
+// the current test if the fixture cannot be built. It panics once tests of
+// the suite ran in parallel, as it cannot tell which one is calling it:
+// use GetFor in such tests.
+func (f *Fixtures) Get(name string) interface{} {
+	tb := f.tb
+	if tb == nil {
+		f.mu.Lock()
+		parallel := f.parallel
+		f.mu.Unlock()
+		if parallel {
+			panic(fmt.Sprintf("suite: Fixtures.Get(%q) called while tests run in parallel: use Fixtures.GetFor", name))
+		}
+		tb = f.current()
+	}
+	return f.GetFor(tb, name)
+}
+
+// GetFor is like Get for the test, or subtest started with Suite.Run,
+// tb. Tests running in parallel must use it with the *testing.T they
+// receive.
+func (f *Fixtures) GetFor(tb testing.TB, name string) interface{} {
+	tb.Helper()
+	value, err := f.get(tb, name)
+	if err != nil {
+		tb.Fatalf("fixture %q: %s", name, err)
+	}
+	return value
+}
+
+func (f *Fixtures) get(tb testing.TB, name string) (interface{}, error) {
+	f.mu.Lock()
+	fixture, ok := f.fixtures[name]
+	if !ok {
+		f.mu.Unlock()
+		return nil, errors.New("not registered")
+	}
+	if n := len(f.building); n > 0 {
+		// name is a dependency of the fixture being built.
+		dependent := f.building[n-1]
+		if scope := f.fixtures[dependent].Scope; fixture.Scope > scope {
+			f.mu.Unlock()
+			return nil, fmt.Errorf("%s fixture cannot be used by %s fixture %q", fixture.Scope, scope, dependent)
+		}
+	}
+	frame, err := f.frame(tb, fixture.Scope)
+	if err != nil {
+		f.mu.Unlock()
+		return nil, err
+	}
+	for {
+		if value, ok := frame.values[name]; ok {
+			f.mu.Unlock()
+			return value, nil
+		}
+		for i, building := range f.building {
+			if building == name {
+				cycle := append(append([]string{}, f.building[i:]...), name)
+				f.mu.Unlock()
+				return nil, fmt.Errorf("dependency cycle: %s", strings.Join(cycle, " -> "))
+			}
+		}
+		built, ok := frame.building[name]
+		if !ok {
+			break
+		}
+		// Another test running in parallel is building the fixture.
+		f.mu.Unlock()
+		<-built
+		f.mu.Lock()
+	}
+	built := make(chan struct{})
+	frame.building[name] = built
+	f.mu.Unlock()
+
+	value, err := f.build(tb, name, fixture)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(frame.building, name)
+	close(built)
+	if err != nil {
+		return nil, err
+	}
+	frame.values[name] = value
+	frame.order = append(frame.order, name)
+	return value, nil
+}
+
+// build builds the dependencies of the named fixture then its value.
+func (f *Fixtures) build(tb testing.TB, name string, fixture Fixture) (interface{}, error) {
+	fixtures := &Fixtures{
+		fixtureRegistry: f.fixtureRegistry,
+		tb:              tb,
+		building:        append(append([]string{}, f.building...), name),
+	}
+	for _, dep := range fixture.Deps {
+		if _, err := fixtures.get(tb, dep); err != nil {
+			return nil, fmt.Errorf("dependency %q: %s", dep, err)
+		}
+	}
+
+	value, err := fixture.Setup(fixtures)
+	if err != nil {
+		return nil, fmt.Errorf("setup failed: %s", err)
+	}
+	return value, nil
+}
+
+// frame returns the frame where fixtures with the given scope used by tb
+// are stored. f.mu must be held.
+func (f *Fixtures) frame(tb testing.TB, scope Scope) (*fixtureFrame, error) {
+	current := f.frames[tb]
+	if scope == SubTestScope {
+		if current != nil && current.scope == SubTestScope {
+			return current, nil
+		}
+		return nil, errors.New("subtest fixture used outside of a subtest")
+	}
+	for frame := current; frame != nil; frame = frame.parent {
+		if frame.scope == scope {
+			return frame, nil
+		}
+	}
+	return nil, fmt.Errorf("%s fixture used outside of a %s", scope, scope)
+}
+
+// begin starts the suite, test or subtest tb, in which fixtures of the
+// given scope can be built. parent is the enclosing suite or test, or nil
+// for a suite.
+func (f *Fixtures) begin(tb testing.TB, scope Scope, parent testing.TB) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.frames[tb] = &fixtureFrame{
+		scope:    scope,
+		parent:   f.frames[parent],
+		values:   make(map[string]interface{}),
+		building: make(map[string]chan struct{}),
+	}
+	if scope == TestScope {
+		f.tests++
+		f.parallel = f.parallel || f.tests > 1
+	}
+}
+
+// end tears down, in reverse order of construction, the fixtures built
+// in tb since the matching call to begin. Teardown errors and panics fail
+// tb without preventing the other fixtures from being torn down.
+func (f *Fixtures) end(tb testing.TB) {
+	tb.Helper()
+
+	f.mu.Lock()
+	frame := f.frames[tb]
+	delete(f.frames, tb)
+	if frame.scope == TestScope {
+		f.tests--
+	}
+	fixtures := make([]Fixture, len(frame.order))
+	for i, name := range frame.order {
+		fixtures[i] = f.fixtures[name]
+	}
+	f.mu.Unlock()
+
+	for i := len(frame.order) - 1; i >= 0; i-- {
+		name := frame.order[i]
+		f.teardown(tb, name, fixtures[i], frame.values[name])
+	}
+}
+func (f *Fixtures) teardown(t testing.TB, name string, fixture Fixture, value interface{}) {
+	t.Helper()
+	if fixture.Teardown == nil {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			t.Errorf("fixture %q teardown panicked: %v\n%s", name, r, debug.Stack())
+		}
+	}()
+	if err := fixture.Teardown(value); err != nil {
+		t.Errorf("fixture %q teardown failed: %s", name, err)
+	}
+}
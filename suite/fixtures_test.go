@@ -0,0 +1,310 @@
+package suite
+
+import (
+	"errors"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fixtureSuite struct {
+	Suite
+	events []string
+}
+
+func (s *fixtureSuite) record(event string) {
+	s.events = append(s.events, event)
+}
+
+func (s *fixtureSuite) fixture(name string, scope Scope, deps ...string) Fixture {
+	return Fixture{
+		Scope: scope,
+		Deps:  deps,
+		Setup: func(*Fixtures) (interface{}, error) {
+			s.record("setup " + name)
+			return name + " value", nil
+		},
+		Teardown: func(value interface{}) error {
+			s.record("teardown " + value.(string))
+			return nil
+		},
+	}
+}
+
+func (s *fixtureSuite) RegisterFixtures(fixtures *Fixtures) {
+	fixtures.Register("db", s.fixture("db", SuiteScope))
+	fixtures.Register("repo", s.fixture("repo", TestScope, "db"))
+	fixtures.Register("unused", s.fixture("unused", SuiteScope))
+	fixtures.Register("tx", Fixture{
+		Scope: SubTestScope,
+		Setup: func(fixtures *Fixtures) (interface{}, error) {
+			s.record("setup tx using " + fixtures.Get("repo").(string))
+			return "tx value", nil
+		},
+		Teardown: func(value interface{}) error {
+			s.record("teardown " + value.(string))
+			return nil
+		},
+	})
+}
+
+func (s *fixtureSuite) TearDownTest() {
+	s.record("TearDownTest")
+}
+
+func (s *fixtureSuite) TearDownSuite() {
+	s.record("TearDownSuite")
+}
+
+func (s *fixtureSuite) TestA() {
+	s.record("TestA")
+	s.Equal("repo value", s.Fixtures().Get("repo"))
+	s.Equal("repo value", s.Fixtures().Get("repo"))
+	s.Run("sub", func() {
+		s.Equal("tx value", s.Fixtures().Get("tx"))
+	})
+}
+
+func (s *fixtureSuite) TestB() {
+	s.record("TestB")
+	s.Equal("db value", s.Fixtures().Get("db"))
+	s.Equal("repo value", s.Fixtures().Get("repo"))
+}
+
+func TestSuiteFixtures(t *testing.T) {
+	suite := new(fixtureSuite)
+	Run(t, suite)
+
+	assert.Equal(t, []string{
+		"TestA",
+		"setup db",
+		"setup repo",
+		"setup tx using repo value",
+		"teardown tx value",
+		"TearDownTest",
+		"teardown repo value",
+		"TestB",
+		"setup repo",
+		"TearDownTest",
+		"teardown repo value",
+		"TearDownSuite",
+		"teardown db value",
+	}, suite.events)
+}
+
+type fixtureErrorSuite struct {
+	Suite
+	tornDown []string
+}
+
+func (s *fixtureErrorSuite) RegisterFixtures(fixtures *Fixtures) {
+	teardown := func(name string) func(interface{}) error {
+		return func(interface{}) error {
+			s.tornDown = append(s.tornDown, name)
+			if name == "failingTeardown" {
+				return errors.New("oops")
+			}
+			if name == "panickingTeardown" {
+				panic("oops")
+			}
+			return nil
+		}
+	}
+	value := func(*Fixtures) (interface{}, error) { return nil, nil }
+
+	fixtures.Register("a", Fixture{Scope: TestScope, Deps: []string{"b"}, Setup: value})
+	fixtures.Register("b", Fixture{Scope: TestScope, Deps: []string{"a"}, Setup: value})
+	fixtures.Register("perTest", Fixture{Scope: TestScope, Setup: value, Teardown: teardown("perTest")})
+	fixtures.Register("perSuite", Fixture{Scope: SuiteScope, Deps: []string{"perTest"}, Setup: value})
+	fixtures.Register("missingDep", Fixture{Scope: TestScope, Deps: []string{"missing"}, Setup: value})
+	fixtures.Register("failingSetup", Fixture{Scope: TestScope, Setup: func(*Fixtures) (interface{}, error) {
+		return nil, errors.New("oops")
+	}})
+	fixtures.Register("failingTeardown", Fixture{Scope: TestScope, Setup: value, Teardown: teardown("failingTeardown")})
+	fixtures.Register("panickingTeardown", Fixture{Scope: TestScope, Setup: value, Teardown: teardown("panickingTeardown")})
+	fixtures.Register("subtest", Fixture{Scope: SubTestScope, Setup: value})
+}
+
+func (s *fixtureErrorSuite) TestCycle() {
+	s.Fixtures().Get("a")
+}
+
+func (s *fixtureErrorSuite) TestNarrowerDependency() {
+	s.Fixtures().Get("perSuite")
+}
+
+func (s *fixtureErrorSuite) TestMissingDependency() {
+	s.Fixtures().Get("missingDep")
+}
+
+func (s *fixtureErrorSuite) TestNotRegistered() {
+	s.Fixtures().Get("missing")
+}
+
+func (s *fixtureErrorSuite) TestFailingSetup() {
+	s.Fixtures().Get("failingSetup")
+}
+
+func (s *fixtureErrorSuite) TestSubTestFixtureOutsideSubtest() {
+	s.Fixtures().Get("subtest")
+}
+
+func (s *fixtureErrorSuite) TestTeardownErrors() {
+	s.Fixtures().Get("panickingTeardown")
+	s.Fixtures().Get("failingTeardown")
+	s.Fixtures().Get("perTest")
+	panic("oops in test")
+}
+
+func TestSuiteFixtureErrors(t *testing.T) {
+	suite := new(fixtureErrorSuite)
+	capture := StdoutCapture{}
+	capture.StartCapture()
+	ok := testing.RunTests(
+		allTestsFilter,
+		[]testing.InternalTest{{
+			Name: t.Name() + "/fixtureErrorSuite",
+			F: func(t *testing.T) {
+				Run(t, suite)
+			},
+		}},
+	)
+	output, err := capture.StopCapture()
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	for _, test := range []string{
+		"TestCycle",
+		"TestNarrowerDependency",
+		"TestMissingDependency",
+		"TestNotRegistered",
+		"TestFailingSetup",
+		"TestSubTestFixtureOutsideSubtest",
+		"TestTeardownErrors",
+	} {
+		assert.Contains(t, output, "--- FAIL: "+t.Name()+"/fixtureErrorSuite/"+test+" ")
+	}
+	for _, message := range []string{
+		`fixture "a": dependency "b": dependency "a": dependency cycle: a -> b -> a`,
+		`fixture "perSuite": dependency "perTest": test fixture cannot be used by suite fixture "perSuite"`,
+		`fixture "missingDep": dependency "missing": not registered`,
+		`fixture "missing": not registered`,
+		`fixture "failingSetup": setup failed: oops`,
+		`fixture "subtest": subtest fixture used outside of a subtest`,
+		`fixture "failingTeardown" teardown failed: oops`,
+		`fixture "panickingTeardown" teardown panicked: oops`,
+	} {
+		assert.True(t, strings.Contains(output, message), "output should contain %q", message)
+	}
+
+	assert.Equal(t, []string{"perTest", "failingTeardown", "panickingTeardown"}, suite.tornDown)
+}
+
+type parallelFixtureSuite struct {
+	Suite
+	mu        sync.Mutex
+	dbSetups  int
+	connNext  int
+	conns     []int
+	tornDown  []interface{}
+	getPanics []interface{}
+}
+
+func (s *parallelFixtureSuite) RegisterFixtures(fixtures *Fixtures) {
+	fixtures.Register("db", Fixture{
+		Scope: SuiteScope,
+		Setup: func(*Fixtures) (interface{}, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.dbSetups++
+			return "db", nil
+		},
+		Teardown: s.teardown,
+	})
+	fixtures.Register("conn", Fixture{
+		Scope: TestScope,
+		Deps:  []string{"db"},
+		Setup: func(*Fixtures) (interface{}, error) {
+			// Let the other tests run while the fixture is being built.
+			runtime.Gosched()
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.connNext++
+			return s.connNext, nil
+		},
+		Teardown: s.teardown,
+	})
+}
+
+func (s *parallelFixtureSuite) teardown(value interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tornDown = append(s.tornDown, value)
+	return nil
+}
+
+func (s *parallelFixtureSuite) test(t *testing.T) {
+	t.Parallel()
+
+	conn := s.Fixtures().GetFor(t, "conn").(int)
+	assert.Equal(t, conn, s.Fixtures().GetFor(t, "conn"))
+	assert.Equal(t, "db", s.Fixtures().GetFor(t, "db"))
+
+	getPanic := func() (r interface{}) {
+		defer func() { r = recover() }()
+		s.Fixtures().Get("conn")
+		return nil
+	}()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.conns = append(s.conns, conn)
+	s.getPanics = append(s.getPanics, getPanic)
+}
+
+func (s *parallelFixtureSuite) TestA(t *testing.T) { s.test(t) }
+func (s *parallelFixtureSuite) TestB(t *testing.T) { s.test(t) }
+func (s *parallelFixtureSuite) TestC(t *testing.T) { s.test(t) }
+
+func TestSuiteFixturesParallel(t *testing.T) {
+	suite := new(parallelFixtureSuite)
+	t.Run("parallelFixtureSuite", func(t *testing.T) {
+		Run(t, suite)
+	})
+
+	assert.Equal(t, 1, suite.dbSetups)
+	assert.ElementsMatch(t, []int{1, 2, 3}, suite.conns, "each test must get its own test fixture")
+	require.Len(t, suite.tornDown, 4)
+	assert.ElementsMatch(t, []interface{}{1, 2, 3}, suite.tornDown[:3])
+	assert.Equal(t, "db", suite.tornDown[3])
+
+	// Get cannot tell which of the tests running in parallel calls it.
+	require.Len(t, suite.getPanics, 3)
+	for _, r := range suite.getPanics {
+		assert.Equal(t, `suite: Fixtures.Get("conn") called while tests run in parallel: use Fixtures.GetFor`, r)
+	}
+}
+
+func TestFixturesRegisterTwice(t *testing.T) {
+	fixtures := newFixtures(func() testing.TB { return t })
+	setup := func(*Fixtures) (interface{}, error) { return nil, nil }
+	fixtures.Register("a", Fixture{Setup: setup})
+
+	assert.PanicsWithValue(t, `suite: fixture "a" registered twice`, func() {
+		fixtures.Register("a", Fixture{Setup: setup})
+	})
+	assert.PanicsWithValue(t, `suite: fixture "b" has no Setup function`, func() {
+		fixtures.Register("b", Fixture{})
+	})
+}
+
+func TestScopeString(t *testing.T) {
+	assert.Equal(t, "suite", SuiteScope.String())
+	assert.Equal(t, "test", TestScope.String())
+	assert.Equal(t, "subtest", SubTestScope.String())
+	assert.Equal(t, "Scope(42)", Scope(42).String())
+}
@@ -0,0 +1,132 @@
+//go:build go1.18
+// +build go1.18
+
+package suite
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// RunFuzz runs the method of suite with the given name as the fuzz target
+// of f. The method takes the fuzz arguments, with the types supported by
+// testing.F.Fuzz, optionally preceded by a *testing.T, and returns
+// nothing. Seed inputs are added with f.Add before calling RunFuzz.
+//
+// SetupSuite and TearDownSuite run once per fuzzing process, and SetupTest,
+// BeforeTest, AfterTest and TearDownTest around each fuzz input, with the
+// suite's T method returning the *testing.T of the input, so assertions
+// such as suite.Equal report to it.
+func RunFuzz(f *testing.F, suite TestingSuite, methodName string) {
+	f.Helper()
+
+	method, ok := reflect.TypeOf(suite).MethodByName(methodName)
+	if !ok {
+		f.Fatalf("testify: suite %T has no method %s", suite, methodName)
+	}
+	fuzzArgs, err := fuzzMethodArgs(method)
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	suite.SetS(suite)
+	if s, ok := suite.(interface{ setTB(testing.TB) }); ok {
+		s.setTB(f)
+	}
+
+	suiteName := reflect.TypeOf(suite).Elem().Name()
+	suiteCtx, cancelSuite := context.WithCancel(context.WithValue(context.Background(), suiteNameKey{}, suiteName))
+	defer cancelSuite()
+	setContext(suite, suiteCtx)
+
+	hooks := newSuiteHooks(suite)
+
+	fixtures := newFixtures(func() testing.TB {
+		if t := suite.T(); t != nil {
+			return t
+		}
+		return f
+	})
+	setFixtures(suite, fixtures)
+	for _, hook := range hooks.setup((*WithFixtures)(nil)) {
+		hook.(WithFixtures).RegisterFixtures(fixtures)
+	}
+	fixtures.begin(f, SuiteScope, nil)
+	defer fixtures.end(f)
+
+	for _, hook := range hooks.setup((*SetupAllSuite)(nil)) {
+		hook.(SetupAllSuite).SetupSuite()
+	}
+	defer func() {
+		for _, hook := range hooks.teardown((*TearDownAllSuite)(nil)) {
+			hook.(TearDownAllSuite).TearDownSuite()
+		}
+	}()
+
+	in := append([]reflect.Type{testingTType}, fuzzArgs...)
+	target := reflect.MakeFunc(reflect.FuncOf(in, nil, false), func(args []reflect.Value) []reflect.Value {
+		t := args[0].Interface().(*testing.T)
+
+		parentT := suite.T()
+		suite.SetT(t)
+
+		ctx, cancel := newTestContext(suiteCtx, t, "", 0)
+		setContext(suite, ctx)
+
+		fixtures.begin(t, TestScope, f)
+		defer fixtures.end(t)
+
+		defer recoverAndFailOnPanic(t)
+		defer func() {
+			for _, hook := range hooks.teardown((*AfterTest)(nil)) {
+				hook.(AfterTest).AfterTest(suiteName, method.Name)
+			}
+
+			for _, hook := range hooks.teardown((*TearDownTestSuite)(nil)) {
+				hook.(TearDownTestSuite).TearDownTest()
+			}
+
+			cancel()
+			setContext(suite, suiteCtx)
+			suite.SetT(parentT)
+		}()
+
+		for _, hook := range hooks.setup((*SetupTestSuite)(nil)) {
+			hook.(SetupTestSuite).SetupTest()
+		}
+		for _, hook := range hooks.setup((*BeforeTest)(nil)) {
+			hook.(BeforeTest).BeforeTest(suiteName, method.Name)
+		}
+
+		callArgs := []reflect.Value{reflect.ValueOf(suite)}
+		if len(fuzzArgs) < method.Type.NumIn()-1 {
+			callArgs = append(callArgs, args[0])
+		}
+		method.Func.Call(append(callArgs, args[1:]...))
+		return nil
+	})
+
+	f.Fuzz(target.Interface())
+}
+
+// fuzzMethodArgs returns the types of the fuzz arguments of method, after
+// the receiver and the optional *testing.T.
+func fuzzMethodArgs(method reflect.Method) ([]reflect.Type, error) {
+	typ := method.Type
+	first := 1
+	if typ.NumIn() > 1 && typ.In(1) == testingTType {
+		first = 2
+	}
+	if typ.NumOut() > 0 || typ.NumIn() == first || typ.IsVariadic() {
+		return nil, fmt.Errorf("testify: fuzz method %s has unsupported signature: "+
+			"expected at least one fuzz argument, optionally preceded by a *testing.T, and no result", method.Name)
+	}
+
+	args := make([]reflect.Type, 0, typ.NumIn()-first)
+	for i := first; i < typ.NumIn(); i++ {
+		args = append(args, typ.In(i))
+	}
+	return args, nil
+}
@@ -0,0 +1,101 @@
+//go:build go1.18
+// +build go1.18
+
+package suite
+
+import (
+	"flag"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fuzzSuite struct {
+	Suite
+	setupSuiteCount    int
+	tearDownSuiteCount int
+	setupTestCount     int
+	tearDownTestCount  int
+	inputs             []string
+	fixtureSetupCount  int
+}
+
+func (s *fuzzSuite) RegisterFixtures(fixtures *Fixtures) {
+	fixtures.Register("prefix", Fixture{
+		Scope: SuiteScope,
+		Setup: func(*Fixtures) (interface{}, error) {
+			s.fixtureSetupCount++
+			return "input:", nil
+		},
+	})
+}
+
+func (s *fuzzSuite) SetupSuite() {
+	s.setupSuiteCount++
+	s.Nil(s.T())
+}
+
+func (s *fuzzSuite) TearDownSuite() {
+	s.tearDownSuiteCount++
+}
+
+func (s *fuzzSuite) SetupTest() {
+	s.setupTestCount++
+}
+
+func (s *fuzzSuite) TearDownTest() {
+	s.tearDownTestCount++
+}
+
+func (s *fuzzSuite) FuzzInput(input string, n int) {
+	s.NotNil(s.T())
+	s.Equal(s.T().Name(), TestNameFromContext(s.Context()))
+	s.inputs = append(s.inputs, s.Fixtures().Get("prefix").(string)+input)
+}
+
+func (s *fuzzSuite) FuzzWithT(t *testing.T, input []byte) {
+	assert.Equal(t, s.T(), t)
+}
+
+func FuzzSuite(f *testing.F) {
+	s := new(fuzzSuite)
+	f.Add("a", 1)
+	f.Add("b", 2)
+	f.Cleanup(func() {
+		if flag.Lookup("test.fuzz").Value.String() != "" {
+			// The fuzzing engine spreads the inputs among processes.
+			return
+		}
+		assert.Equal(f, 1, s.setupSuiteCount)
+		assert.Equal(f, 1, s.tearDownSuiteCount)
+		assert.Equal(f, 1, s.fixtureSetupCount)
+		assert.Equal(f, len(s.inputs), s.setupTestCount)
+		assert.Equal(f, len(s.inputs), s.tearDownTestCount)
+		assert.Equal(f, []string{"input:a", "input:b"}, s.inputs)
+	})
+	RunFuzz(f, s, "FuzzInput")
+}
+
+func FuzzSuiteWithT(f *testing.F) {
+	f.Add([]byte("a"))
+	RunFuzz(f, new(fuzzSuite), "FuzzWithT")
+}
+
+type invalidFuzzSuite struct {
+	Suite
+}
+
+func (s *invalidFuzzSuite) FuzzNoArgument() {}
+
+func TestFuzzMethodArgs(t *testing.T) {
+	method, _ := reflect.TypeOf(&fuzzSuite{}).MethodByName("FuzzWithT")
+	args, err := fuzzMethodArgs(method)
+	assert.NoError(t, err)
+	assert.Len(t, args, 1)
+
+	method, _ = reflect.TypeOf(&invalidFuzzSuite{}).MethodByName("FuzzNoArgument")
+	_, err = fuzzMethodArgs(method)
+	assert.EqualError(t, err, "testify: fuzz method FuzzNoArgument has unsupported signature: "+
+		"expected at least one fuzz argument, optionally preceded by a *testing.T, and no result")
+}
@@ -0,0 +1,124 @@
+package suite
+
+import (
+	"reflect"
+	"runtime"
+	"unsafe"
+)
+
+// suiteHooks finds the receivers of the hook methods, such as SetupTest,
+// of a suite and, if it implements WithEmbeddedHooks, of the structs
+// embedded in it, so that layered suites do not have to call the hooks of
+// the suites they embed.
+type suiteHooks struct {
+	suite interface{}
+	// embedded are pointers to the structs embedded in suite, the most
+	// deeply embedded first.
+	embedded []interface{}
+}
+
+func newSuiteHooks(suite interface{}) *suiteHooks {
+	hooks := &suiteHooks{suite: suite}
+	if withEmbeddedHooks, ok := suite.(WithEmbeddedHooks); ok && withEmbeddedHooks.EmbeddedHooks() {
+		hooks.embedded = embeddedStructs(reflect.ValueOf(suite), make(map[interface{}]bool))
+	}
+	return hooks
+}
+
+// setup returns the receivers of the method of hook, a nil pointer to a
+// hook interface such as (*SetupTestSuite)(nil), in the order they are
+// called before a test: embedded structs first, then the suite itself.
+// Only the structs that declare the method are returned, so a method
+// promoted from an embedded struct is called once.
+func (h *suiteHooks) setup(hook interface{}) []interface{} {
+	// The suite is nil when Suite.Run is called on a suite that was not
+	// run by Run.
+	if h.suite == nil {
+		return nil
+	}
+	iface := reflect.TypeOf(hook).Elem()
+	name := iface.Method(0).Name
+
+	var receivers []interface{}
+	for _, embedded := range h.embedded {
+		if typ := reflect.TypeOf(embedded); typ.Implements(iface) && declaresMethod(typ, name) {
+			receivers = append(receivers, embedded)
+		}
+	}
+	if typ := reflect.TypeOf(h.suite); typ.Implements(iface) {
+		// The method of the suite may be promoted from a field that is
+		// not walked, such as an embedded interface.
+		if len(receivers) == 0 || declaresMethod(typ, name) {
+			receivers = append(receivers, h.suite)
+		}
+	}
+	return receivers
+}
+
+// teardown is like setup in the order hooks are called after a test: the
+// suite first, then the embedded structs.
+func (h *suiteHooks) teardown(hook interface{}) []interface{} {
+	receivers := h.setup(hook)
+	for i, j := 0, len(receivers)-1; i < j; i, j = i+1, j-1 {
+		receivers[i], receivers[j] = receivers[j], receivers[i]
+	}
+	return receivers
+}
+
+// embeddedStructs returns pointers to the structs embedded, directly or
+// not, in the struct v points to, the most deeply embedded first.
+func embeddedStructs(v reflect.Value, visited map[interface{}]bool) []interface{} {
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	elem := v.Elem()
+
+	var embedded []interface{}
+	for i := 0; i < elem.NumField(); i++ {
+		if !elem.Type().Field(i).Anonymous {
+			continue
+		}
+
+		// Embedded structs are often unexported types, whose fields
+		// cannot be used through reflection without going through unsafe.
+		field := elem.Field(i)
+		var ptr reflect.Value
+		switch {
+		case field.Kind() == reflect.Struct:
+			ptr = reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr()))
+		case field.Kind() == reflect.Ptr && !field.IsNil() && field.Type().Elem().Kind() == reflect.Struct:
+			ptr = reflect.NewAt(field.Type().Elem(), unsafe.Pointer(field.Pointer()))
+		default:
+			continue
+		}
+
+		key := ptr.Interface()
+		if visited[key] {
+			continue
+		}
+		visited[key] = true
+
+		embedded = append(embedded, embeddedStructs(ptr, visited)...)
+		embedded = append(embedded, key)
+	}
+	return embedded
+}
+
+// declaresMethod reports whether the named method of typ is declared by
+// typ, or by the type typ points to, rather than promoted from an embedded
+// field. Promoted methods are implemented by compiler generated wrappers.
+func declaresMethod(typ reflect.Type, name string) bool {
+	if typ.Kind() == reflect.Ptr && declaresMethod(typ.Elem(), name) {
+		return true
+	}
+	method, ok := typ.MethodByName(name)
+	if !ok {
+		return false
+	}
+	fn := runtime.FuncForPC(method.Func.Pointer())
+	if fn == nil {
+		return true
+	}
+	file, _ := fn.FileLine(fn.Entry())
+	return file != "<autogenerated>"
+}
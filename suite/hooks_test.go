@@ -0,0 +1,169 @@
+package suite
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type hookLog struct {
+	calls []string
+}
+
+func (l *hookLog) record(call string) {
+	l.calls = append(l.calls, call)
+}
+
+type databaseSuite struct {
+	Suite
+	log *hookLog
+}
+
+func (s *databaseSuite) SetupSuite()      { s.log.record("database SetupSuite") }
+func (s *databaseSuite) TearDownSuite()   { s.log.record("database TearDownSuite") }
+func (s *databaseSuite) SetupTest()       { s.log.record("database SetupTest") }
+func (s *databaseSuite) TearDownTest()    { s.log.record("database TearDownTest") }
+func (s *databaseSuite) SetupSubTest()    { s.log.record("database SetupSubTest") }
+func (s *databaseSuite) TearDownSubTest() { s.log.record("database TearDownSubTest") }
+
+type cacheSuite struct {
+	*databaseSuite
+}
+
+func (s *cacheSuite) SetupTest()    { s.log.record("cache SetupTest") }
+func (s *cacheSuite) TearDownTest() { s.log.record("cache TearDownTest") }
+
+type userRepoSuite struct {
+	cacheSuite
+}
+
+func (s *userRepoSuite) EmbeddedHooks() bool { return true }
+
+func (s *userRepoSuite) SetupTest()       { s.log.record("userRepo SetupTest") }
+func (s *userRepoSuite) TearDownSuite()   { s.log.record("userRepo TearDownSuite") }
+func (s *userRepoSuite) SetupSubTest()    { s.log.record("userRepo SetupSubTest") }
+func (s *userRepoSuite) TearDownSubTest() { s.log.record("userRepo TearDownSubTest") }
+
+func (s *userRepoSuite) TestUser() {
+	s.log.record("TestUser")
+	s.Run("sub", func() {
+		s.log.record("sub")
+	})
+}
+
+func TestSuiteEmbeddedHooks(t *testing.T) {
+	log := new(hookLog)
+	Run(t, &userRepoSuite{cacheSuite{&databaseSuite{log: log}}})
+
+	assert.Equal(t, []string{
+		"database SetupSuite",
+		"database SetupTest",
+		"cache SetupTest",
+		"userRepo SetupTest",
+		"TestUser",
+		"database SetupSubTest",
+		"userRepo SetupSubTest",
+		"sub",
+		"userRepo TearDownSubTest",
+		"database TearDownSubTest",
+		// TearDownTest is promoted from cacheSuite and only called once.
+		"cache TearDownTest",
+		"database TearDownTest",
+		"userRepo TearDownSuite",
+		"database TearDownSuite",
+	}, log.calls)
+}
+
+type defaultHooksSuite struct {
+	databaseSuite
+}
+
+func (s *defaultHooksSuite) SetupTest() { s.log.record("default SetupTest") }
+
+func (s *defaultHooksSuite) TestNothing() {}
+
+func TestSuiteEmbeddedHooksDefault(t *testing.T) {
+	log := new(hookLog)
+	Run(t, &defaultHooksSuite{databaseSuite{log: log}})
+
+	assert.Equal(t, []string{
+		// Promoted hooks are still called.
+		"database SetupSuite",
+		"default SetupTest",
+		"database TearDownTest",
+		"database TearDownSuite",
+	}, log.calls)
+}
+
+type explicitBaseSuite struct {
+	databaseSuite
+}
+
+func (s *explicitBaseSuite) SetupTest() {
+	s.databaseSuite.SetupTest()
+	s.log.record("explicit SetupTest")
+}
+
+func (s *explicitBaseSuite) TestNothing() {}
+
+func TestSuiteEmbeddedHooksCalledExplicitly(t *testing.T) {
+	log := new(hookLog)
+	Run(t, &explicitBaseSuite{databaseSuite{log: log}})
+
+	// Suites calling the hooks of the suites they embed, as they had to
+	// before WithEmbeddedHooks, call them once.
+	assert.Equal(t, []string{
+		"database SetupSuite",
+		"database SetupTest",
+		"explicit SetupTest",
+		"database TearDownTest",
+		"database TearDownSuite",
+	}, log.calls)
+}
+
+func TestSuiteRunWithoutSuite(t *testing.T) {
+	// SetS is only called by Run.
+	suite := new(Suite)
+	suite.SetT(t)
+
+	var ran bool
+	assert.True(t, suite.Run("sub", func() { ran = true }))
+	assert.True(t, ran)
+}
+
+type valueReceiverBase struct{}
+
+func (valueReceiverBase) SetupTest() {}
+
+type promotingSuite struct {
+	valueReceiverBase
+}
+
+func TestDeclaresMethod(t *testing.T) {
+	assert.True(t, declaresMethod(reflect.TypeOf(&databaseSuite{}), "SetupTest"))
+	assert.True(t, declaresMethod(reflect.TypeOf(&cacheSuite{}), "SetupTest"))
+	assert.False(t, declaresMethod(reflect.TypeOf(&cacheSuite{}), "SetupSuite"))
+	assert.False(t, declaresMethod(reflect.TypeOf(&userRepoSuite{}), "TearDownTest"))
+	assert.False(t, declaresMethod(reflect.TypeOf(&userRepoSuite{}), "Missing"))
+
+	assert.True(t, declaresMethod(reflect.TypeOf(valueReceiverBase{}), "SetupTest"))
+	assert.True(t, declaresMethod(reflect.TypeOf(&valueReceiverBase{}), "SetupTest"))
+	assert.False(t, declaresMethod(reflect.TypeOf(&promotingSuite{}), "SetupTest"))
+}
+
+type nilPointerSuite struct {
+	*databaseSuite
+}
+
+func (s *nilPointerSuite) EmbeddedHooks() bool { return true }
+
+func TestEmbeddedStructsNilPointer(t *testing.T) {
+	suite := &nilPointerSuite{}
+	hooks := newSuiteHooks(suite)
+	assert.Empty(t, hooks.embedded)
+	assert.Equal(t, []interface{}{suite}, hooks.setup((*SetupTestSuite)(nil)))
+	// As before embedded hooks were supported, a method promoted through
+	// a nil pointer is called on the suite.
+	assert.Equal(t, []interface{}{suite}, hooks.setup((*SetupAllSuite)(nil)))
+}
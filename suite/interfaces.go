@@ -1,6 +1,9 @@
 package suite
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 // TestingSuite can store and return the current *testing.T context
 // generated by 'go test'.
@@ -64,3 +67,105 @@ type SetupSubTest interface {
 type TearDownSubTest interface {
 	TearDownSubTest()
 }
+
+// WithRetries has a MaxRetries method, which returns how many times a
+// failing test in the suite is re-run, with fresh SetupTest and
+// TearDownTest calls, before it is reported as failed. Failed attempts
+// are not reported as failures: their output is logged by the test. A test
+// that passes on a retry is reported as flaky, and the flaky tests of the
+// suite are printed once it ends.
+//
+// The attempts before the last one run as separate tests, through
+// testing.RunTests, with os.Stdout redirected to capture their output.
+// Anything else the program writes to os.Stdout meanwhile, such as the
+// output of tests running in parallel, ends up in the log of the attempt.
+type WithRetries interface {
+	MaxRetries(testName string) int
+}
+
+// WithTimeout has a Timeout method, which returns the maximum duration of
+// each test in the suite. A test that runs longer fails, the
+// context returned by Suite.Context is cancelled and TearDownTest and
+// AfterTest are still run. A zero duration means no timeout.
+//
+// The test method then runs on a goroutine of its own, where it must not
+// call t.Parallel: implement WithParallel instead. A timed out test keeps
+// running until it returns, with its assertions through the suite
+// reporting to whichever test is current, so it should return once its
+// context is done.
+type WithTimeout interface {
+	Timeout(testName string) time.Duration
+}
+
+// WithParallel has a Parallel method, which reports whether the named test
+// runs in parallel with the other parallel tests of the suite. Run calls
+// t.Parallel for such a test before SetupTest, so its timeout only counts
+// the time it actually runs.
+type WithParallel interface {
+	Parallel(testName string) bool
+}
+
+// WithFixtures has a RegisterFixtures method, which is called before
+// SetupSuite to declare the fixtures used by the tests of the suite.
+type WithFixtures interface {
+	RegisterFixtures(fixtures *Fixtures)
+}
+
+// AfterTestWithResult has a function to be executed right after the test
+// finishes and receives the suite and test names and the result of the
+// test as input. It runs after TearDownTest, the teardown of the test's
+// fixtures and the leak check, so the result accounts for failures in
+// those too. With WithRetries, it is called for every attempt, failed
+// attempts of a test that passes on retry being reported as TestFailed.
+type AfterTestWithResult interface {
+	AfterTestWithResult(suiteName, testName string, result TestResult)
+}
+
+// WithPanicHandler has a HandleTestPanic method, which will run when a
+// test in the suite panics, before AfterTest and TearDownTest. It receives
+// the recovered value and the stack trace of the panic.
+type WithPanicHandler interface {
+	HandleTestPanic(testName string, value interface{}, stack []byte)
+}
+
+// BenchmarkingSuite can store and return the current *testing.B context
+// generated by 'go test -bench'.
+type BenchmarkingSuite interface {
+	B() *testing.B
+	SetB(*testing.B)
+}
+
+// SetupBenchmarkSuite has a SetupBenchmark method, which will run before
+// each benchmark in the suite. Its duration is excluded from the timing
+// of the benchmark.
+type SetupBenchmarkSuite interface {
+	SetupBenchmark()
+}
+
+// TearDownBenchmarkSuite has a TearDownBenchmark method, which will run
+// after each benchmark in the suite. Its duration is excluded from the
+// timing of the benchmark.
+type TearDownBenchmarkSuite interface {
+	TearDownBenchmark()
+}
+
+// WithEmbeddedHooks has an EmbeddedHooks method. When it returns true, the
+// hooks of the structs embedded in the suite, such as a base suite
+// providing shared fixtures, are called along with the hooks of the suite:
+// setup hooks of embedded structs first, teardown hooks of embedded structs
+// last. The hooks of the suite must then not call the hooks of the structs
+// it embeds, or they are called twice. By default, only the hooks of the
+// suite are called, and it is responsible for calling the hooks of the
+// structs it embeds.
+type WithEmbeddedHooks interface {
+	EmbeddedHooks() bool
+}
+
+// WithLeakCheck has a LeakCheck method. The goroutines started by a test
+// of a suite implementing it, from SetupTest to TearDownTest, must have
+// exited after TearDownTest, or the test fails with their stacks. Tests
+// running in parallel fail, as the goroutines of the other tests would be
+// reported.
+type WithLeakCheck interface {
+	LeakCheck() LeakCheck
+}
@@ -0,0 +1,56 @@
+package suite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/internal/goroutines"
+)
+
+// LeakCheck configures the detection of the goroutines leaked by the tests
+// of a suite implementing WithLeakCheck.
+type LeakCheck struct {
+	// GracePeriod is how long to wait, after TearDownTest, for the
+	// goroutines started by the test to exit. If zero, it is one second.
+	GracePeriod time.Duration
+
+	// Ignore lists known background goroutines that are not reported as
+	// leaked. A goroutine is ignored if its stack contains one of these
+	// strings, typically the name of the function it runs, such as
+	// "net/http.(*persistConn).readLoop".
+	Ignore []string
+}
+
+// errParallelLeakCheck is the failure of the tests of a suite implementing
+// WithLeakCheck that run in parallel: the goroutines of the other tests
+// would be reported as leaked.
+const errParallelLeakCheck = "suite: WithLeakCheck cannot check tests running in parallel"
+
+// startLeakCheck returns a function failing t with the goroutines started
+// since startLeakCheck was called that are still running, or nil if suite
+// does not implement WithLeakCheck. Tests for which parallel reports true,
+// when the check starts or ends, fail instead.
+func startLeakCheck(suite interface{}, t *testing.T, parallel func() bool) func() {
+	withLeakCheck, ok := suite.(WithLeakCheck)
+	if !ok {
+		return nil
+	}
+	if parallel() {
+		t.Fatal(errParallelLeakCheck)
+	}
+	check := withLeakCheck.LeakCheck()
+	if check.GracePeriod == 0 {
+		check.GracePeriod = time.Second
+	}
+	snapshot := goroutines.Take()
+	return func() {
+		t.Helper()
+		if parallel() {
+			t.Error(errParallelLeakCheck)
+			return
+		}
+		if leaked := snapshot.Leaked(check.GracePeriod, check.Ignore); len(leaked) > 0 {
+			t.Errorf("test leaked %d goroutine(s):\n%s", len(leaked), goroutines.Format(leaked))
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package suite
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestResult is the outcome of a test of a suite.
+type TestResult int
+
+const (
+	// TestPassed is the result of a test that neither failed nor was skipped.
+	TestPassed TestResult = iota
+	// TestFailed is the result of a test that failed without panicking.
+	TestFailed
+	// TestSkipped is the result of a test that was skipped without failing.
+	TestSkipped
+	// TestPanicked is the result of a test that panicked.
+	TestPanicked
+)
+
+func (r TestResult) String() string {
+	switch r {
+	case TestPassed:
+		return "passed"
+	case TestFailed:
+		return "failed"
+	case TestSkipped:
+		return "skipped"
+	case TestPanicked:
+		return "panicked"
+	}
+	return fmt.Sprintf("TestResult(%d)", int(r))
+}
+
+func newTestResult(t *testing.T, panicValue interface{}) TestResult {
+	switch {
+	case panicValue != nil:
+		return TestPanicked
+	case t.Failed():
+		return TestFailed
+	case t.Skipped():
+		return TestSkipped
+	}
+	return TestPassed
+}
@@ -1,6 +1,9 @@
 package suite
 
-import "time"
+import (
+	"sort"
+	"time"
+)
 
 // SuiteInformation stats stores stats for the whole suite execution.
 type SuiteInformation struct {
@@ -13,6 +16,11 @@ type TestInformation struct {
 	TestName   string
 	Start, End time.Time
 	Passed     bool
+	// Attempts is the number of times the test was run, including retries.
+	Attempts int
+	// Flaky is true when the test failed at least once before passing on
+	// a retry.
+	Flaky bool
 }
 
 func newSuiteInformation() *SuiteInformation {
@@ -24,9 +32,14 @@ func newSuiteInformation() *SuiteInformation {
 }
 
 func (s SuiteInformation) start(testName string) {
+	attempts := 1
+	if previous, ok := s.TestStats[testName]; ok {
+		attempts = previous.Attempts + 1
+	}
 	s.TestStats[testName] = &TestInformation{
 		TestName: testName,
 		Start:    time.Now(),
+		Attempts: attempts,
 	}
 }
 
@@ -35,6 +48,10 @@ func (s SuiteInformation) end(testName string, passed bool) {
 	s.TestStats[testName].Passed = passed
 }
 
+func (s SuiteInformation) flaky(testName string) {
+	s.TestStats[testName].Flaky = true
+}
+
 func (s SuiteInformation) Passed() bool {
 	for _, stats := range s.TestStats {
 		if !stats.Passed {
@@ -44,3 +61,16 @@ func (s SuiteInformation) Passed() bool {
 
 	return true
 }
+
+// Flaky returns the sorted names of the tests that passed only after
+// being retried.
+func (s SuiteInformation) Flaky() []string {
+	var flaky []string
+	for _, stats := range s.TestStats {
+		if stats.Flaky {
+			flaky = append(flaky, stats.TestName)
+		}
+	}
+	sort.Strings(flaky)
+	return flaky
+}
@@ -27,3 +27,14 @@ func TestPassedReturnsFalseWhenSomeTestFails(t *testing.T) {
 
 	assert.False(t, sinfo.Passed())
 }
+
+func TestFlakyReturnsSortedFlakyTests(t *testing.T) {
+	sinfo := newSuiteInformation()
+	sinfo.TestStats = map[string]*TestInformation{
+		"Test3": {TestName: "Test3", Passed: true, Flaky: true},
+		"Test1": {TestName: "Test1", Passed: true, Flaky: true},
+		"Test2": {TestName: "Test2", Passed: true},
+	}
+
+	assert.Equal(t, []string{"Test1", "Test3"}, sinfo.Flaky())
+}
@@ -1,13 +1,21 @@
 package suite
 
 import (
+	"bytes"
+	"context"
 	"flag"
 	"fmt"
+	"io"
+	"math/rand"
 	"os"
 	"reflect"
 	"regexp"
+	"runtime"
 	"runtime/debug"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -17,6 +25,7 @@ import (
 
 var allTestsFilter = func(_, _ string) (bool, error) { return true, nil }
 var matchMethod = flag.String("testify.m", "", "regular expression to select tests of the testify suite to run")
+var shuffle = flag.String("testify.shuffle", "off", "randomize the execution order of the tests of testify suites: off, on or a seed")
 
 // Suite is a basic testing suite with methods for storing and
 // retrieving the current *testing.T context.
@@ -26,6 +35,10 @@ type Suite struct {
 	mu      sync.RWMutex
 	require *require.Assertions
 	t       *testing.T
+	b       *testing.B
+	ctx     context.Context
+
+	fixtures *Fixtures
 
 	// Parent suite to have access to the implemented methods of parent struct
 	s TestingSuite
@@ -47,6 +60,72 @@ func (suite *Suite) SetT(t *testing.T) {
 	suite.require = require.New(t)
 }
 
+// setTB makes the assertions of the suite report to tb, for use outside of
+// a test, such as in the SetupSuite of a fuzz target.
+func (suite *Suite) setTB(tb testing.TB) {
+	suite.mu.Lock()
+	defer suite.mu.Unlock()
+	suite.Assertions = assert.New(tb)
+	suite.require = require.New(tb)
+}
+
+// B retrieves the current *testing.B context, set while running
+// benchmarks with RunBenchmarks.
+func (suite *Suite) B() *testing.B {
+	suite.mu.RLock()
+	defer suite.mu.RUnlock()
+	return suite.b
+}
+
+// SetB sets the current *testing.B context.
+func (suite *Suite) SetB(b *testing.B) {
+	suite.mu.Lock()
+	defer suite.mu.Unlock()
+	suite.b = b
+	suite.Assertions = assert.New(b)
+	suite.require = require.New(b)
+}
+
+// Context returns the context of the current test, or of the current
+// subtest inside Suite.Run. It is cancelled when the test ends or when its
+// timeout, if any, expires, and its deadline is the one of the test
+// binary (see testing.T.Deadline). Outside of a test it returns the
+// context of the suite, which is cancelled once all the tests in the
+// suite have run.
+//
+// The names of the suite and of the test are available through
+// SuiteNameFromContext and TestNameFromContext.
+func (suite *Suite) Context() context.Context {
+	suite.mu.RLock()
+	defer suite.mu.RUnlock()
+	if suite.ctx == nil {
+		return context.Background()
+	}
+	return suite.ctx
+}
+
+func (suite *Suite) setContext(ctx context.Context) {
+	suite.mu.Lock()
+	defer suite.mu.Unlock()
+	suite.ctx = ctx
+}
+
+// Fixtures returns the fixture registry of the suite.
+func (suite *Suite) Fixtures() *Fixtures {
+	suite.mu.RLock()
+	defer suite.mu.RUnlock()
+	if suite.fixtures == nil {
+		panic("'Fixtures' must not be called before 'Run'")
+	}
+	return suite.fixtures
+}
+
+func (suite *Suite) setFixtures(fixtures *Fixtures) {
+	suite.mu.Lock()
+	defer suite.mu.Unlock()
+	suite.fixtures = fixtures
+}
+
 // SetS needs to set the current test suite as parent
 // to get access to the parent methods
 func (suite *Suite) SetS(s TestingSuite) {
@@ -77,41 +156,63 @@ func (suite *Suite) Assert() *assert.Assertions {
 	return suite.Assertions
 }
 
-func recoverAndFailOnPanic(t *testing.T) {
+func recoverAndFailOnPanic(t testing.TB) {
 	t.Helper()
 	r := recover()
 	failOnPanic(t, r)
 }
 
-func failOnPanic(t *testing.T, r interface{}) {
+func failOnPanic(t testing.TB, r interface{}) {
 	t.Helper()
 	if r != nil {
-		t.Errorf("test panicked: %v\n%s", r, debug.Stack())
-		t.FailNow()
+		failWithPanic(t, r, debug.Stack())
 	}
 }
 
+func failWithPanic(t testing.TB, r interface{}, stack []byte) {
+	t.Helper()
+	t.Errorf("test panicked: %v\n%s", r, stack)
+	t.FailNow()
+}
+
 // Run provides suite functionality around golang subtests.  It should be
 // called in place of t.Run(name, func(t *testing.T)) in test suite code.
 // The passed-in func will be executed as a subtest with a fresh instance of t.
 // Provides compatibility with go test pkg -run TestSuite/TestName/SubTestName.
 func (suite *Suite) Run(name string, subtest func()) bool {
 	oldT := suite.T()
+	oldCtx := suite.Context()
 
 	return oldT.Run(name, func(t *testing.T) {
 		suite.SetT(t)
 		defer suite.SetT(oldT)
 
-		defer recoverAndFailOnPanic(t)
+		ctx, cancel := newTestContext(oldCtx, t, "", 0)
+		suite.setContext(ctx)
+		defer func() {
+			cancel()
+			suite.setContext(oldCtx)
+		}()
 
-		if setupSubTest, ok := suite.s.(SetupSubTest); ok {
-			setupSubTest.SetupSubTest()
+		if fixtures := suite.fixtures; fixtures != nil {
+			fixtures.begin(t, SubTestScope, oldT)
+			defer fixtures.end(t)
 		}
 
-		if tearDownSubTest, ok := suite.s.(TearDownSubTest); ok {
-			defer tearDownSubTest.TearDownSubTest()
+		defer recoverAndFailOnPanic(t)
+
+		hooks := newSuiteHooks(suite.s)
+
+		for _, hook := range hooks.setup((*SetupSubTest)(nil)) {
+			hook.(SetupSubTest).SetupSubTest()
 		}
 
+		defer func() {
+			for _, hook := range hooks.teardown((*TearDownSubTest)(nil)) {
+				hook.(TearDownSubTest).TearDownSubTest()
+			}
+		}()
+
 		subtest()
 	})
 }
@@ -124,16 +225,66 @@ func Run(t *testing.T, suite TestingSuite) {
 	suite.SetT(t)
 	suite.SetS(suite)
 
+	methodFinder := reflect.TypeOf(suite)
+	suiteName := methodFinder.Elem().Name()
+
+	// tearDown are called in reverse order once all the tests of the suite
+	// have ended. Tests calling t.Parallel only resume once Run has
+	// returned, in which case the suite is torn down by a cleanup of t.
+	var tearDown []func()
+	var running, returned int32
+	defer func() {
+		atomic.StoreInt32(&returned, 1)
+		if atomic.LoadInt32(&running) == 0 {
+			callInReverse(tearDown)
+			return
+		}
+		t.Cleanup(func() {
+			defer recoverAndFailOnPanic(t)
+			callInReverse(tearDown)
+		})
+	}()
+
+	seed, shuffled, err := shuffleSeed(*shuffle)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "testify: %s\n", err)
+		os.Exit(1)
+	}
+	if shuffled {
+		// Logged last, as tearing down the suite can fail it too.
+		tearDown = append(tearDown, func() {
+			if t.Failed() {
+				t.Logf("tests of %s were shuffled, rerun with -testify.shuffle=%d to reproduce the order", suiteName, seed)
+			}
+		})
+	}
+
+	suiteCtx, cancelSuite := newTestContext(context.Background(), t, suiteName, 0)
+	tearDown = append(tearDown, cancelSuite)
+	setContext(suite, suiteCtx)
+
+	hooks := newSuiteHooks(suite)
+
+	fixtures := newFixtures(func() testing.TB { return suite.T() })
+	setFixtures(suite, fixtures)
+	for _, hook := range hooks.setup((*WithFixtures)(nil)) {
+		hook.(WithFixtures).RegisterFixtures(fixtures)
+	}
+	fixtures.begin(t, SuiteScope, nil)
+	tearDown = append(tearDown, func() { fixtures.end(t) })
+
 	var suiteSetupDone bool
 
+	// mu guards flaky and stats, updated by parallel tests.
+	var mu sync.Mutex
+	var flaky []string
+
 	var stats *SuiteInformation
 	if _, ok := suite.(WithStats); ok {
 		stats = newSuiteInformation()
 	}
 
 	tests := []testing.InternalTest{}
-	methodFinder := reflect.TypeOf(suite)
-	suiteName := methodFinder.Elem().Name()
 
 	for i := 0; i < methodFinder.NumMethod(); i++ {
 		method := methodFinder.Method(i)
@@ -153,77 +304,377 @@ func Run(t *testing.T, suite TestingSuite) {
 				stats.Start = time.Now()
 			}
 
-			if setupAllSuite, ok := suite.(SetupAllSuite); ok {
-				setupAllSuite.SetupSuite()
+			for _, hook := range hooks.setup((*SetupAllSuite)(nil)) {
+				hook.(SetupAllSuite).SetupSuite()
 			}
 
 			suiteSetupDone = true
 		}
 
-		test := testing.InternalTest{
-			Name: method.Name,
-			F: func(t *testing.T) {
-				parentT := suite.T()
-				suite.SetT(t)
-				defer recoverAndFailOnPanic(t)
-				defer func() {
-					t.Helper()
+		suiteT := t
+		runTest := func(t *testing.T) {
+			suite.SetT(t)
 
-					r := recover()
+			timeout := testTimeout(suite, method.Name)
+			ctx, cancel := newTestContext(suiteCtx, t, "", timeout)
+			setContext(suite, ctx)
 
-					if stats != nil {
-						passed := !t.Failed() && r == nil
-						stats.end(method.Name, passed)
-					}
+			var panicValue interface{}
+			var panicStack []byte
 
-					if afterTestSuite, ok := suite.(AfterTest); ok {
-						afterTestSuite.AfterTest(suiteName, method.Name)
-					}
+			// The result is reported last, as tearing down fixtures and
+			// checking for leaked goroutines can fail the test.
+			defer recoverAndFailOnPanic(t)
+			defer func() {
+				t.Helper()
+
+				result := newTestResult(t, panicValue)
+
+				if stats != nil {
+					mu.Lock()
+					stats.end(method.Name, result == TestPassed || result == TestSkipped)
+					mu.Unlock()
+				}
+
+				for _, hook := range hooks.teardown((*AfterTestWithResult)(nil)) {
+					hook.(AfterTestWithResult).AfterTestWithResult(suiteName, method.Name, result)
+				}
+
+				setContext(suite, suiteCtx)
+				suite.SetT(suiteT)
+				if panicValue != nil {
+					failWithPanic(t, panicValue, panicStack)
+				}
+			}()
+
+			// A test running once Run has returned called t.Parallel.
+			parallel := func() bool { return atomic.LoadInt32(&returned) == 1 }
+			if checkLeaks := startLeakCheck(suite, t, parallel); checkLeaks != nil {
+				defer checkLeaks()
+			}
+
+			fixtures.begin(t, TestScope, suiteT)
+			defer fixtures.end(t)
+
+			defer recoverAndFailOnPanic(t)
+			defer func() {
+				t.Helper()
 
-					if tearDownTestSuite, ok := suite.(TearDownTestSuite); ok {
-						tearDownTestSuite.TearDownTest()
+				if r := recover(); r != nil {
+					panicValue, panicStack = r, debug.Stack()
+				}
+
+				if panicValue != nil {
+					for _, hook := range hooks.teardown((*WithPanicHandler)(nil)) {
+						hook.(WithPanicHandler).HandleTestPanic(method.Name, panicValue, panicStack)
 					}
+				}
 
-					suite.SetT(parentT)
-					failOnPanic(t, r)
-				}()
+				for _, hook := range hooks.teardown((*AfterTest)(nil)) {
+					hook.(AfterTest).AfterTest(suiteName, method.Name)
+				}
 
-				if setupTestSuite, ok := suite.(SetupTestSuite); ok {
-					setupTestSuite.SetupTest()
+				for _, hook := range hooks.teardown((*TearDownTestSuite)(nil)) {
+					hook.(TearDownTestSuite).TearDownTest()
 				}
-				if beforeTestSuite, ok := suite.(BeforeTest); ok {
-					beforeTestSuite.BeforeTest(methodFinder.Elem().Name(), method.Name)
+
+				cancel()
+			}()
+
+			for _, hook := range hooks.setup((*SetupTestSuite)(nil)) {
+				hook.(SetupTestSuite).SetupTest()
+			}
+			for _, hook := range hooks.setup((*BeforeTest)(nil)) {
+				hook.(BeforeTest).BeforeTest(suiteName, method.Name)
+			}
+
+			if stats != nil {
+				mu.Lock()
+				stats.start(method.Name)
+				mu.Unlock()
+			}
+
+			call := func() {
+				method.Func.Call(testMethodArgs(method, suite, t, ctx))
+			}
+			if timeout > 0 {
+				panicValue, panicStack = callWithTimeout(t, timeout, cancel, call)
+			} else {
+				call()
+			}
+		}
+
+		if err := checkTestMethod(method); err != nil {
+			tests = append(tests, testing.InternalTest{
+				Name: method.Name,
+				F: func(t *testing.T) {
+					t.Fatal(err)
+				},
+			})
+			continue
+		}
+
+		test := testing.InternalTest{
+			Name: method.Name,
+			F: func(t *testing.T) {
+				atomic.AddInt32(&running, 1)
+				defer atomic.AddInt32(&running, -1)
+
+				if withParallel, ok := suite.(WithParallel); ok && withParallel.Parallel(method.Name) {
+					t.Parallel()
 				}
 
-				if stats != nil {
-					stats.start(method.Name)
+				retries := 0
+				if withRetries, ok := suite.(WithRetries); ok && !failFast() {
+					retries = withRetries.MaxRetries(method.Name)
 				}
 
-				method.Func.Call([]reflect.Value{reflect.ValueOf(suite)})
+				for attempt := 1; attempt <= retries+1; attempt++ {
+					if attempt > retries {
+						// Last attempt, report the result on the real test.
+						runTest(t)
+					} else if passed, output := runIsolated(t, runTest); !passed {
+						t.Logf("attempt %d of %d failed, retrying:\n%s", attempt, retries+1, output)
+						continue
+					}
+
+					if attempt > 1 && !t.Failed() {
+						mu.Lock()
+						flaky = append(flaky, method.Name)
+						if stats != nil {
+							stats.flaky(method.Name)
+						}
+						mu.Unlock()
+					}
+					return
+				}
 			},
 		}
 		tests = append(tests, test)
 	}
 	if suiteSetupDone {
-		defer func() {
-			if tearDownAllSuite, ok := suite.(TearDownAllSuite); ok {
-				tearDownAllSuite.TearDownSuite()
+		tearDown = append(tearDown, func() {
+			for _, hook := range hooks.teardown((*TearDownAllSuite)(nil)) {
+				hook.(TearDownAllSuite).TearDownSuite()
 			}
 
 			if suiteWithStats, measureStats := suite.(WithStats); measureStats {
 				stats.End = time.Now()
 				suiteWithStats.HandleStats(suiteName, stats)
 			}
-		}()
+
+			// Printed rather than logged, as go test only shows the
+			// log of passing tests with -v.
+			if len(flaky) > 0 {
+				fmt.Printf("testify: flaky tests in %s (passed on retry): %s\n", suiteName, strings.Join(flaky, ", "))
+			}
+		})
+	}
+
+	if shuffled {
+		rand.New(rand.NewSource(seed)).Shuffle(len(tests), func(i, j int) {
+			tests[i], tests[j] = tests[j], tests[i]
+		})
 	}
 
 	runTests(t, tests)
 }
 
+// callInReverse calls fns from the last to the first. The remaining
+// functions are still called when one panics.
+func callInReverse(fns []func()) {
+	for _, f := range fns {
+		defer f()
+	}
+}
+
+// shuffleSeed parses the value of the -testify.shuffle flag. It returns the
+// seed to shuffle tests with, or false if they must run in method order.
+func shuffleSeed(value string) (int64, bool, error) {
+	switch value {
+	case "off":
+		return 0, false, nil
+	case "on":
+		return time.Now().UnixNano(), true, nil
+	}
+	seed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid value %q for -testify.shuffle: expected off, on or an integer seed", value)
+	}
+	return seed, true, nil
+}
+
+// setContext stores ctx in suite if it embeds Suite.
+func setContext(suite interface{}, ctx context.Context) {
+	if s, ok := suite.(interface{ setContext(context.Context) }); ok {
+		s.setContext(ctx)
+	}
+}
+
+// setFixtures stores fixtures in suite if it embeds Suite.
+func setFixtures(suite interface{}, fixtures *Fixtures) {
+	if s, ok := suite.(interface{ setFixtures(*Fixtures) }); ok {
+		s.setFixtures(fixtures)
+	}
+}
+
+// isolatedMu serializes the runs of runIsolated, which redirect os.Stdout.
+var isolatedMu sync.Mutex
+
+// runIsolated runs test as a separate top-level test named after t, so
+// that a failure does not mark t as failed. It reports whether test passed
+// and returns its output. The output is captured rather than printed: the
+// "--- FAIL" line of a failed attempt would otherwise be reported as a
+// failure of t by go test -json. test runs once, whatever the values of
+// -count and -cpu.
+func runIsolated(t *testing.T, test func(t *testing.T)) (bool, string) {
+	t.Helper()
+
+	isolatedMu.Lock()
+	defer isolatedMu.Unlock()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("testify: cannot capture the output of a retried test: %s", err)
+	}
+	var output bytes.Buffer
+	copied := make(chan struct{})
+	go func() {
+		defer close(copied)
+		_, _ = io.Copy(&output, r)
+		r.Close()
+	}()
+
+	stdout := os.Stdout
+	os.Stdout = w
+	procs := runtime.GOMAXPROCS(0)
+
+	var ran bool
+	passed := testing.RunTests(regexp.MatchString, []testing.InternalTest{{
+		Name: t.Name(),
+		F: func(t *testing.T) {
+			// RunTests runs tests once per value of -count and -cpu.
+			if ran {
+				return
+			}
+			ran = true
+			test(t)
+		},
+	}})
+
+	runtime.GOMAXPROCS(procs)
+	os.Stdout = stdout
+	w.Close()
+	<-copied
+
+	return passed, withoutTestStatus(output.String())
+}
+
+// testOutputMarkers are the control characters framing the output of
+// tests under go test -json: ^V starts status lines, ^O and ^N surround
+// errors.
+var testOutputMarkers = strings.NewReplacer("\x16", "", "\x0f", "", "\x0e", "")
+
+// withoutTestStatus removes the lines reporting the status of a test, such
+// as "=== RUN" and "--- FAIL", from its output so that another test can log
+// it.
+func withoutTestStatus(output string) string {
+	var b strings.Builder
+	for _, line := range strings.SplitAfter(testOutputMarkers.Replace(output), "\n") {
+		trimmed := strings.TrimLeft(line, " \t")
+		if strings.HasPrefix(trimmed, "=== ") || strings.HasPrefix(trimmed, "--- ") {
+			continue
+		}
+		b.WriteString(line)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// failFast reports whether the -failfast flag is set. Failed retry attempts
+// count as test failures and would stop the whole run in that mode.
+func failFast() bool {
+	f := flag.Lookup("test.failfast")
+	if f == nil {
+		return false
+	}
+	getter, ok := f.Value.(flag.Getter)
+	if !ok {
+		return false
+	}
+	enabled, _ := getter.Get().(bool)
+	return enabled
+}
+
+var (
+	testingTType = reflect.TypeOf((*testing.T)(nil))
+	contextType  = reflect.TypeOf((*context.Context)(nil)).Elem()
+)
+
+// checkTestMethod returns an error if the signature of a test method is
+// not supported by testMethodArgs. Test methods take no argument, a
+// *testing.T or a context.Context, and return nothing.
+func checkTestMethod(method reflect.Method) error {
+	return checkMethodSignature(method, "test", "a *testing.T or a context.Context", testingTType, contextType)
+}
+
+// checkMethodSignature returns an error unless method returns nothing and
+// takes either no argument or a single argument of one of the given types,
+// described by expected.
+func checkMethodSignature(method reflect.Method, kind, expected string, argTypes ...reflect.Type) error {
+	typ := method.Type
+	// The first input is the receiver.
+	if typ.NumOut() == 0 && typ.NumIn() == 1 {
+		return nil
+	}
+	if typ.NumOut() == 0 && typ.NumIn() == 2 {
+		for _, argType := range argTypes {
+			if typ.In(1) == argType {
+				return nil
+			}
+		}
+	}
+
+	// Describe the signature without the receiver.
+	in := make([]reflect.Type, 0, typ.NumIn()-1)
+	for i := 1; i < typ.NumIn(); i++ {
+		in = append(in, typ.In(i))
+	}
+	out := make([]reflect.Type, 0, typ.NumOut())
+	for i := 0; i < typ.NumOut(); i++ {
+		out = append(out, typ.Out(i))
+	}
+	signature := reflect.FuncOf(in, out, typ.IsVariadic())
+
+	return fmt.Errorf("testify: %s method %s has unsupported signature %s: "+
+		"expected no argument, %s, and no result",
+		kind, method.Name, signature, expected)
+}
+
+// testMethodArgs returns the arguments to call method with, including the
+// receiver, for the test t whose context is ctx.
+func testMethodArgs(method reflect.Method, suite TestingSuite, t *testing.T, ctx context.Context) []reflect.Value {
+	args := []reflect.Value{reflect.ValueOf(suite)}
+	if method.Type.NumIn() == 2 {
+		switch method.Type.In(1) {
+		case testingTType:
+			args = append(args, reflect.ValueOf(t))
+		case contextType:
+			args = append(args, reflect.ValueOf(&ctx).Elem())
+		}
+	}
+	return args
+}
+
 // Filtering method according to set regular expression
 // specified command-line argument -m
 func methodFilter(name string) (bool, error) {
-	if ok, _ := regexp.MatchString("^Test", name); !ok {
+	return prefixedMethodFilter("Test", name)
+}
+
+// prefixedMethodFilter is like methodFilter for methods starting with
+// prefix.
+func prefixedMethodFilter(prefix, name string) (bool, error) {
+	if !strings.HasPrefix(name, prefix) {
 		return false, nil
 	}
 	return regexp.MatchString(*matchMethod, name)
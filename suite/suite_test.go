@@ -2,13 +2,17 @@ package suite
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
 	"flag"
+	"fmt"
 	"io/ioutil"
 	"math/rand"
 	"os"
 	"os/exec"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -746,3 +750,788 @@ func TestUnInitializedSuites(t *testing.T) {
 		})
 	})
 }
+
+type flakySuite struct {
+	Suite
+	retries       int
+	setupCount    int
+	tearDownCount int
+	flakyRuns     int
+	failingRuns   int
+	stats         *SuiteInformation
+}
+
+func (s *flakySuite) MaxRetries(testName string) int {
+	return s.retries
+}
+
+func (s *flakySuite) SetupTest() {
+	s.setupCount++
+}
+
+func (s *flakySuite) TearDownTest() {
+	s.tearDownCount++
+}
+
+func (s *flakySuite) HandleStats(suiteName string, stats *SuiteInformation) {
+	s.stats = stats
+}
+
+func (s *flakySuite) TestFlaky() {
+	s.flakyRuns++
+	s.Equal(3, s.flakyRuns)
+}
+
+func (s *flakySuite) TestAlwaysFails() {
+	s.failingRuns++
+	s.Fail("always fails")
+}
+
+func TestSuiteRetries(t *testing.T) {
+	suite := &flakySuite{retries: 2}
+	ok := testing.RunTests(
+		allTestsFilter,
+		[]testing.InternalTest{{
+			Name: t.Name() + "/flakySuite",
+			F: func(t *testing.T) {
+				Run(t, suite)
+			},
+		}},
+	)
+
+	assert.False(t, ok, "TestAlwaysFails should make the suite fail")
+
+	assert.Equal(t, 3, suite.flakyRuns)
+	assert.Equal(t, 3, suite.failingRuns)
+	assert.Equal(t, 6, suite.setupCount)
+	assert.Equal(t, 6, suite.tearDownCount)
+
+	require.NotNil(t, suite.stats)
+	assert.Equal(t, []string{"TestFlaky"}, suite.stats.Flaky())
+
+	flakyStats := suite.stats.TestStats["TestFlaky"]
+	assert.True(t, flakyStats.Passed)
+	assert.True(t, flakyStats.Flaky)
+	assert.Equal(t, 3, flakyStats.Attempts)
+
+	failingStats := suite.stats.TestStats["TestAlwaysFails"]
+	assert.False(t, failingStats.Passed)
+	assert.False(t, failingStats.Flaky)
+	assert.Equal(t, 3, failingStats.Attempts)
+}
+
+func TestSuiteWithoutRetries(t *testing.T) {
+	suite := &flakySuite{}
+	ok := testing.RunTests(
+		allTestsFilter,
+		[]testing.InternalTest{{
+			Name: t.Name() + "/flakySuite",
+			F: func(t *testing.T) {
+				Run(t, suite)
+			},
+		}},
+	)
+
+	assert.False(t, ok)
+	assert.Equal(t, 1, suite.flakyRuns)
+	assert.Equal(t, 1, suite.failingRuns)
+	assert.Empty(t, suite.stats.Flaky())
+}
+
+type flakyOnceSuite struct {
+	Suite
+	runs int
+}
+
+func (s *flakyOnceSuite) MaxRetries(testName string) int {
+	return 1
+}
+
+func (s *flakyOnceSuite) TestFlakyOnce() {
+	s.runs++
+	s.Equal(2, s.runs, "fails on the first attempt")
+}
+
+// TestSuiteRetriesHelper runs flakyOnceSuite as a top-level suite for
+// TestSuiteRetriesJSON.
+func TestSuiteRetriesHelper(t *testing.T) {
+	if os.Getenv("TESTIFY_RETRIES_HELPER") == "" {
+		t.Skip("run by TestSuiteRetriesJSON")
+	}
+	Run(t, new(flakyOnceSuite))
+}
+
+func TestSuiteRetriesJSON(t *testing.T) {
+	// The failed attempt must not be reported as a failure by go test -json,
+	// nor be run again by -count.
+	cmd := exec.Command("go", "test", "-json", "-count=2", "-run", "^TestSuiteRetriesHelper$")
+	cmd.Env = append(os.Environ(), "TESTIFY_RETRIES_HELPER=1")
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	err := cmd.Run()
+	t.Log(out.String())
+	require.NoError(t, err)
+
+	var passes int
+	decoder := json.NewDecoder(&out)
+	for decoder.More() {
+		var event struct {
+			Action string
+			Test   string
+		}
+		require.NoError(t, decoder.Decode(&event))
+		assert.NotEqual(t, "fail", event.Action, "unexpected failure of %q", event.Test)
+		if event.Action == "pass" && event.Test == "TestSuiteRetriesHelper/TestFlakyOnce" {
+			passes++
+		}
+	}
+	assert.Equal(t, 2, passes)
+}
+
+func TestSuiteRetriesNotVerbose(t *testing.T) {
+	// The flaky tests are reported even when go test does not show the
+	// log of passing tests.
+	cmd := exec.Command("go", "test", "-count=1", "-run", "^TestSuiteRetriesHelper$")
+	cmd.Env = append(os.Environ(), "TESTIFY_RETRIES_HELPER=1")
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	assert.Contains(t, string(out), "testify: flaky tests in flakyOnceSuite (passed on retry): TestFlakyOnce\n")
+	assert.NotContains(t, string(out), "--- FAIL")
+}
+
+type timeoutSuite struct {
+	Suite
+	afterTestNames    []string
+	tearDownTestCount int
+	hungErr           chan error
+	quickCtx          context.Context
+}
+
+func (s *timeoutSuite) Timeout(testName string) time.Duration {
+	if testName == "TestHangs" {
+		return 50 * time.Millisecond
+	}
+	return time.Minute
+}
+
+func (s *timeoutSuite) AfterTest(_, testName string) {
+	s.afterTestNames = append(s.afterTestNames, testName)
+}
+
+func (s *timeoutSuite) TearDownTest() {
+	s.tearDownTestCount++
+}
+
+func (s *timeoutSuite) TestHangs() {
+	ctx := s.Context()
+	<-ctx.Done()
+	s.hungErr <- ctx.Err()
+}
+
+func (s *timeoutSuite) TestQuick() {
+	s.quickCtx = s.Context()
+	s.NoError(s.quickCtx.Err())
+}
+
+func TestSuiteTimeout(t *testing.T) {
+	suite := &timeoutSuite{hungErr: make(chan error, 1)}
+	capture := StdoutCapture{}
+	capture.StartCapture()
+	ok := testing.RunTests(
+		allTestsFilter,
+		[]testing.InternalTest{{
+			Name: t.Name() + "/timeoutSuite",
+			F: func(t *testing.T) {
+				Run(t, suite)
+			},
+		}},
+	)
+	output, err := capture.StopCapture()
+	require.NoError(t, err)
+
+	assert.False(t, ok, "TestHangs should make the suite fail")
+	assert.Contains(t, output, "--- FAIL: TestSuiteTimeout/timeoutSuite/TestHangs")
+	assert.Contains(t, output, "test timed out after 50ms")
+	assert.Contains(t, output, "(*timeoutSuite).TestHangs")
+	assert.NotContains(t, output, "--- FAIL: TestSuiteTimeout/timeoutSuite/TestQuick")
+
+	assert.Equal(t, []string{"TestHangs", "TestQuick"}, suite.afterTestNames)
+	assert.Equal(t, 2, suite.tearDownTestCount)
+
+	select {
+	case err := <-suite.hungErr:
+		assert.Error(t, err)
+	case <-time.After(time.Second):
+		t.Error("the context of the hung test was not cancelled")
+	}
+	assert.Error(t, suite.quickCtx.Err(), "test context should be cancelled once the test ends")
+}
+
+type parallelTimeoutSuite struct {
+	Suite
+	mu     sync.Mutex
+	events []string
+}
+
+func (s *parallelTimeoutSuite) record(event string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, event)
+}
+
+func (s *parallelTimeoutSuite) Timeout(string) time.Duration {
+	return 500 * time.Millisecond
+}
+
+func (s *parallelTimeoutSuite) Parallel(testName string) bool {
+	return testName != "TestSequential"
+}
+
+func (s *parallelTimeoutSuite) test(ctx context.Context) {
+	s.record(TestNameFromContext(ctx))
+	// Together, the tests run longer than the timeout of each of them.
+	time.Sleep(200 * time.Millisecond)
+}
+
+func (s *parallelTimeoutSuite) TestA(ctx context.Context)          { s.test(ctx) }
+func (s *parallelTimeoutSuite) TestB(ctx context.Context)          { s.test(ctx) }
+func (s *parallelTimeoutSuite) TestC(ctx context.Context)          { s.test(ctx) }
+func (s *parallelTimeoutSuite) TestSequential(ctx context.Context) { s.test(ctx) }
+
+func TestSuiteTimeoutParallel(t *testing.T) {
+	suite := new(parallelTimeoutSuite)
+	t.Run("parallelTimeoutSuite", func(t *testing.T) {
+		Run(t, suite)
+		suite.record("Run returned")
+	})
+
+	// The timeout of a parallel test starts once it is released.
+	require.Len(t, suite.events, 5)
+	assert.Equal(t, []string{
+		"TestSuiteTimeoutParallel/parallelTimeoutSuite/TestSequential",
+		"Run returned",
+	}, suite.events[:2])
+	assert.ElementsMatch(t, []string{
+		"TestSuiteTimeoutParallel/parallelTimeoutSuite/TestA",
+		"TestSuiteTimeoutParallel/parallelTimeoutSuite/TestB",
+		"TestSuiteTimeoutParallel/parallelTimeoutSuite/TestC",
+	}, suite.events[2:])
+}
+
+func TestSuiteContextOutsideRun(t *testing.T) {
+	suite := new(timeoutSuite)
+	assert.NotNil(t, suite.Context())
+	assert.NoError(t, suite.Context().Err())
+}
+
+type contextSuite struct {
+	Suite
+	setupSuiteCtx context.Context
+	setupTestCtx  context.Context
+	testCtx       context.Context
+	subtestCtx    context.Context
+}
+
+func (s *contextSuite) SetupSuite() {
+	s.setupSuiteCtx = s.Context()
+}
+
+func (s *contextSuite) SetupTest() {
+	s.setupTestCtx = s.Context()
+}
+
+func (s *contextSuite) TestContext() {
+	s.testCtx = s.Context()
+	s.Equal(s.setupTestCtx, s.testCtx)
+	s.Equal("contextSuite", SuiteNameFromContext(s.testCtx))
+	s.Equal(s.T().Name(), TestNameFromContext(s.testCtx))
+
+	deadline, ok := s.T().Deadline()
+	ctxDeadline, ctxOk := s.testCtx.Deadline()
+	s.Equal(ok, ctxOk)
+	s.Equal(deadline, ctxDeadline)
+
+	s.Run("subtest", func() {
+		s.subtestCtx = s.Context()
+		s.NotEqual(s.testCtx, s.subtestCtx)
+		s.Equal("contextSuite", SuiteNameFromContext(s.subtestCtx))
+		s.Equal(s.T().Name(), TestNameFromContext(s.subtestCtx))
+	})
+
+	s.Error(s.subtestCtx.Err(), "subtest context should be cancelled once the subtest ends")
+	s.NoError(s.testCtx.Err())
+	s.Equal(s.testCtx, s.Context())
+}
+
+func TestSuiteContext(t *testing.T) {
+	suite := new(contextSuite)
+	Run(t, suite)
+
+	assert.Equal(t, "contextSuite", SuiteNameFromContext(suite.setupSuiteCtx))
+	assert.Equal(t, t.Name(), TestNameFromContext(suite.setupSuiteCtx))
+	assert.Error(t, suite.setupSuiteCtx.Err())
+	assert.Error(t, suite.testCtx.Err())
+
+	assert.Empty(t, SuiteNameFromContext(context.Background()))
+	assert.Empty(t, TestNameFromContext(context.Background()))
+}
+
+type resultSuite struct {
+	Suite
+	results     map[string]TestResult
+	panics      map[string]interface{}
+	panicStacks map[string]string
+	calls       []string
+	release     chan struct{}
+}
+
+func (s *resultSuite) RegisterFixtures(fixtures *Fixtures) {
+	fixtures.Register("failingTeardown", Fixture{
+		Scope:    TestScope,
+		Setup:    func(*Fixtures) (interface{}, error) { return nil, nil },
+		Teardown: func(interface{}) error { return errors.New("oops") },
+	})
+}
+
+func (s *resultSuite) LeakCheck() LeakCheck {
+	return LeakCheck{GracePeriod: 10 * time.Millisecond}
+}
+
+func (s *resultSuite) Timeout(testName string) time.Duration {
+	if testName == "TestPanicWithTimeout" {
+		return time.Minute
+	}
+	return 0
+}
+
+func (s *resultSuite) HandleTestPanic(testName string, value interface{}, stack []byte) {
+	s.panics[testName] = value
+	s.panicStacks[testName] = string(stack)
+	s.calls = append(s.calls, "HandleTestPanic "+testName)
+}
+
+func (s *resultSuite) AfterTestWithResult(suiteName, testName string, result TestResult) {
+	s.Equal("resultSuite", suiteName)
+	s.results[testName] = result
+	s.calls = append(s.calls, "AfterTestWithResult "+testName)
+}
+
+func (s *resultSuite) TearDownTest() {
+	s.calls = append(s.calls, "TearDownTest "+s.T().Name())
+}
+
+func (s *resultSuite) TestPass() {}
+
+func (s *resultSuite) TestFail() {
+	s.Fail("failure")
+}
+
+func (s *resultSuite) TestFailNow() {
+	s.Require().Fail("failure")
+}
+
+func (s *resultSuite) TestSkip() {
+	s.T().Skip()
+}
+
+func (s *resultSuite) TestPanic() {
+	panic("oops")
+}
+
+func (s *resultSuite) TestPanicWithTimeout() {
+	panic("oops with timeout")
+}
+
+func (s *resultSuite) TestFixtureTeardownFails() {
+	s.Fixtures().Get("failingTeardown")
+}
+
+func (s *resultSuite) TestLeaksGoroutine() {
+	go func() { <-s.release }()
+}
+
+func TestSuiteAfterTestWithResult(t *testing.T) {
+	suite := &resultSuite{
+		results:     make(map[string]TestResult),
+		panics:      make(map[string]interface{}),
+		panicStacks: make(map[string]string),
+		release:     make(chan struct{}),
+	}
+	defer close(suite.release)
+	ok := testing.RunTests(
+		allTestsFilter,
+		[]testing.InternalTest{{
+			Name: t.Name() + "/resultSuite",
+			F: func(t *testing.T) {
+				Run(t, suite)
+			},
+		}},
+	)
+	assert.False(t, ok)
+
+	assert.Equal(t, map[string]TestResult{
+		"TestPass":             TestPassed,
+		"TestFail":             TestFailed,
+		"TestFailNow":          TestFailed,
+		"TestSkip":             TestSkipped,
+		"TestPanic":            TestPanicked,
+		"TestPanicWithTimeout": TestPanicked,
+		// Failures after TearDownTest are reported too.
+		"TestFixtureTeardownFails": TestFailed,
+		"TestLeaksGoroutine":       TestFailed,
+	}, suite.results)
+
+	assert.Equal(t, map[string]interface{}{
+		"TestPanic":            "oops",
+		"TestPanicWithTimeout": "oops with timeout",
+	}, suite.panics)
+	assert.Contains(t, suite.panicStacks["TestPanic"], "(*resultSuite).TestPanic")
+	assert.Contains(t, suite.panicStacks["TestPanicWithTimeout"], "(*resultSuite).TestPanicWithTimeout")
+
+	var panicCalls []string
+	for _, call := range suite.calls {
+		if strings.HasSuffix(call, "TestPanic") {
+			panicCalls = append(panicCalls, call)
+		}
+	}
+	assert.Equal(t, []string{
+		"HandleTestPanic TestPanic",
+		"TearDownTest " + t.Name() + "/resultSuite/TestPanic",
+		"AfterTestWithResult TestPanic",
+	}, panicCalls)
+}
+
+func TestTestResultString(t *testing.T) {
+	assert.Equal(t, "passed", TestPassed.String())
+	assert.Equal(t, "failed", TestFailed.String())
+	assert.Equal(t, "skipped", TestSkipped.String())
+	assert.Equal(t, "panicked", TestPanicked.String())
+	assert.Equal(t, "TestResult(42)", TestResult(42).String())
+}
+
+type signatureSuite struct {
+	Suite
+	t   *testing.T
+	ctx context.Context
+}
+
+func (s *signatureSuite) TestWithT(t *testing.T) {
+	s.t = t
+	assert.Equal(t, s.T(), t)
+}
+
+func (s *signatureSuite) TestWithContext(ctx context.Context) {
+	s.ctx = ctx
+	s.Equal(s.Context(), ctx)
+}
+
+func (s *signatureSuite) TestWithInt(int) {
+	panic("must not be called")
+}
+
+func (s *signatureSuite) TestWithResult() error {
+	panic("must not be called")
+}
+
+func TestSuiteMethodSignatures(t *testing.T) {
+	suite := new(signatureSuite)
+	capture := StdoutCapture{}
+	capture.StartCapture()
+	ok := testing.RunTests(
+		allTestsFilter,
+		[]testing.InternalTest{{
+			Name: t.Name() + "/signatureSuite",
+			F: func(t *testing.T) {
+				Run(t, suite)
+			},
+		}},
+	)
+	output, err := capture.StopCapture()
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	if assert.NotNil(t, suite.t) {
+		assert.Equal(t, t.Name()+"/signatureSuite/TestWithT", suite.t.Name())
+	}
+	if assert.NotNil(t, suite.ctx) {
+		assert.Equal(t, t.Name()+"/signatureSuite/TestWithContext", TestNameFromContext(suite.ctx))
+	}
+
+	assert.NotContains(t, output, "--- FAIL: "+t.Name()+"/signatureSuite/TestWithT ")
+	assert.NotContains(t, output, "--- FAIL: "+t.Name()+"/signatureSuite/TestWithContext ")
+	assert.Contains(t, output, "test method TestWithInt has unsupported signature func(int): "+
+		"expected no argument, a *testing.T or a context.Context, and no result")
+	assert.Contains(t, output, "test method TestWithResult has unsupported signature func() error")
+	assert.NotContains(t, output, "must not be called")
+}
+
+type parallelSuite struct {
+	Suite
+	mu      sync.Mutex
+	events  []string
+	ctxErrs []error
+}
+
+func (s *parallelSuite) record(event string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, event)
+}
+
+func (s *parallelSuite) TearDownSuite() {
+	s.record("TearDownSuite")
+}
+
+func (s *parallelSuite) TestWithT(t *testing.T) {
+	t.Parallel()
+	s.record(t.Name())
+}
+
+func (s *parallelSuite) TestWithContext(ctx context.Context) {
+	s.T().Parallel()
+
+	s.mu.Lock()
+	s.ctxErrs = append(s.ctxErrs, ctx.Err())
+	s.mu.Unlock()
+	s.record(TestNameFromContext(ctx))
+}
+
+func TestSuiteParallel(t *testing.T) {
+	suite := new(parallelSuite)
+	t.Run("parallelSuite", func(t *testing.T) {
+		Run(t, suite)
+	})
+
+	require.Len(t, suite.events, 3)
+	assert.ElementsMatch(t, []string{
+		t.Name() + "/parallelSuite/TestWithT",
+		t.Name() + "/parallelSuite/TestWithContext",
+	}, suite.events[:2])
+	assert.Equal(t, "TearDownSuite", suite.events[2], "the suite must be torn down after its parallel tests")
+	assert.Equal(t, []error{nil}, suite.ctxErrs, "the context of a parallel test must not be cancelled before it ends")
+}
+
+type shuffleSuite struct {
+	Suite
+	order               []string
+	fail                bool
+	failInTearDownSuite bool
+}
+
+func (s *shuffleSuite) TearDownSuite() {
+	s.False(s.failInTearDownSuite)
+}
+
+func (s *shuffleSuite) BeforeTest(_, testName string) {
+	s.order = append(s.order, testName)
+}
+
+func (s *shuffleSuite) Test1() {}
+func (s *shuffleSuite) Test2() {}
+func (s *shuffleSuite) Test3() {}
+func (s *shuffleSuite) Test4() {}
+func (s *shuffleSuite) Test5() {}
+func (s *shuffleSuite) Test6() {}
+func (s *shuffleSuite) Test7() {}
+func (s *shuffleSuite) Test8() {
+	s.False(s.fail)
+}
+
+func TestSuiteShuffle(t *testing.T) {
+	defer func(old string) { *shuffle = old }(*shuffle)
+	methodOrder := []string{"Test1", "Test2", "Test3", "Test4", "Test5", "Test6", "Test7", "Test8"}
+
+	*shuffle = "off"
+	unshuffled := new(shuffleSuite)
+	Run(t, unshuffled)
+	assert.Equal(t, methodOrder, unshuffled.order)
+
+	*shuffle = "42"
+	first := new(shuffleSuite)
+	Run(t, first)
+	second := new(shuffleSuite)
+	Run(t, second)
+	assert.ElementsMatch(t, methodOrder, first.order)
+	assert.NotEqual(t, methodOrder, first.order)
+	assert.Equal(t, first.order, second.order, "the same seed should give the same order")
+
+	*shuffle = "on"
+	randomized := new(shuffleSuite)
+	Run(t, randomized)
+	assert.ElementsMatch(t, methodOrder, randomized.order)
+}
+
+func TestSuiteShuffleSeedOnFailure(t *testing.T) {
+	defer func(old string) { *shuffle = old }(*shuffle)
+	*shuffle = "42"
+
+	for name, suite := range map[string]*shuffleSuite{
+		"InTest":          {fail: true},
+		"InTearDownSuite": {failInTearDownSuite: true},
+	} {
+		capture := StdoutCapture{}
+		capture.StartCapture()
+		ok := testing.RunTests(
+			allTestsFilter,
+			[]testing.InternalTest{{
+				Name: t.Name() + "/" + name,
+				F: func(t *testing.T) {
+					Run(t, suite)
+				},
+			}},
+		)
+		output, err := capture.StopCapture()
+		require.NoError(t, err)
+		assert.False(t, ok, name)
+		assert.Contains(t, output, "tests of shuffleSuite were shuffled, rerun with -testify.shuffle=42 to reproduce the order", name)
+	}
+}
+
+type setupShuffleSuite struct{ shuffleSuite }
+
+func (s *setupShuffleSuite) SetupSuite() {
+	fmt.Println("setupShuffleSuite: SetupSuite")
+}
+
+// TestSuiteShuffleHelper runs setupShuffleSuite as a top-level suite for
+// TestSuiteShuffleInvalid.
+func TestSuiteShuffleHelper(t *testing.T) {
+	if os.Getenv("TESTIFY_SHUFFLE_HELPER") == "" {
+		t.Skip("run by TestSuiteShuffleInvalid")
+	}
+	Run(t, new(setupShuffleSuite))
+}
+
+func TestSuiteShuffleInvalid(t *testing.T) {
+	// An invalid seed is reported before the suite is set up.
+	cmd := exec.Command("go", "test", "-count=1", "-run", "^TestSuiteShuffleHelper$", "-testify.shuffle=sometimes")
+	cmd.Env = append(os.Environ(), "TESTIFY_SHUFFLE_HELPER=1")
+	out, err := cmd.CombinedOutput()
+	require.Error(t, err, string(out))
+
+	assert.Contains(t, string(out), `testify: invalid value "sometimes" for -testify.shuffle`)
+	assert.NotContains(t, string(out), "setupShuffleSuite: SetupSuite")
+}
+
+func TestShuffleSeed(t *testing.T) {
+	_, shuffled, err := shuffleSeed("off")
+	assert.NoError(t, err)
+	assert.False(t, shuffled)
+
+	_, shuffled, err = shuffleSeed("on")
+	assert.NoError(t, err)
+	assert.True(t, shuffled)
+
+	seed, shuffled, err := shuffleSeed("-12")
+	assert.NoError(t, err)
+	assert.True(t, shuffled)
+	assert.Equal(t, int64(-12), seed)
+
+	_, _, err = shuffleSeed("sometimes")
+	assert.EqualError(t, err, `invalid value "sometimes" for -testify.shuffle: expected off, on or an integer seed`)
+}
+
+type leakSuite struct {
+	Suite
+	release chan struct{}
+	stop    chan struct{}
+}
+
+func (s *leakSuite) LeakCheck() LeakCheck {
+	return LeakCheck{
+		GracePeriod: 50 * time.Millisecond,
+		Ignore:      []string{"leakSuiteBackgroundWorker"},
+	}
+}
+
+func (s *leakSuite) SetupTest() {
+	s.stop = make(chan struct{})
+}
+
+func (s *leakSuite) TearDownTest() {
+	close(s.stop)
+}
+
+func (s *leakSuite) TestLeak() {
+	go func() { <-s.release }()
+}
+
+func (s *leakSuite) TestStoppedInTearDown() {
+	stop := s.stop
+	go func() { <-stop }()
+}
+
+func (s *leakSuite) TestIgnored() {
+	go leakSuiteBackgroundWorker(s.release)
+}
+
+func leakSuiteBackgroundWorker(release chan struct{}) {
+	<-release
+}
+
+func TestSuiteLeakCheck(t *testing.T) {
+	suite := &leakSuite{release: make(chan struct{})}
+	defer close(suite.release)
+
+	capture := StdoutCapture{}
+	capture.StartCapture()
+	ok := testing.RunTests(
+		allTestsFilter,
+		[]testing.InternalTest{{
+			Name: t.Name() + "/leakSuite",
+			F: func(t *testing.T) {
+				Run(t, suite)
+			},
+		}},
+	)
+	output, err := capture.StopCapture()
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.Contains(t, output, "--- FAIL: "+t.Name()+"/leakSuite/TestLeak ")
+	assert.Contains(t, output, "test leaked 1 goroutine(s):")
+	assert.Contains(t, output, "(*leakSuite).TestLeak")
+	assert.NotContains(t, output, "--- FAIL: "+t.Name()+"/leakSuite/TestStoppedInTearDown ")
+	assert.NotContains(t, output, "--- FAIL: "+t.Name()+"/leakSuite/TestIgnored ")
+}
+
+type parallelLeakSuite struct{ Suite }
+
+func (s *parallelLeakSuite) LeakCheck() LeakCheck {
+	return LeakCheck{GracePeriod: 10 * time.Millisecond}
+}
+
+func (s *parallelLeakSuite) Parallel(testName string) bool {
+	return testName == "TestWithParallel"
+}
+
+func (s *parallelLeakSuite) TestCallsParallel(t *testing.T) {
+	t.Parallel()
+}
+
+func (s *parallelLeakSuite) TestSequential() {}
+
+func (s *parallelLeakSuite) TestWithParallel() {}
+
+func TestSuiteLeakCheckParallel(t *testing.T) {
+	capture := StdoutCapture{}
+	capture.StartCapture()
+	ok := testing.RunTests(
+		allTestsFilter,
+		[]testing.InternalTest{{
+			Name: t.Name() + "/parallelLeakSuite",
+			F: func(t *testing.T) {
+				Run(t, new(parallelLeakSuite))
+			},
+		}},
+	)
+	output, err := capture.StopCapture()
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.Contains(t, output, "--- FAIL: "+t.Name()+"/parallelLeakSuite/TestCallsParallel ")
+	assert.Contains(t, output, "--- FAIL: "+t.Name()+"/parallelLeakSuite/TestWithParallel ")
+	assert.Equal(t, 2, strings.Count(output, "suite: WithLeakCheck cannot check tests running in parallel"))
+	assert.NotContains(t, output, "--- FAIL: "+t.Name()+"/parallelLeakSuite/TestSequential ")
+}
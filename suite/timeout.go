@@ -0,0 +1,65 @@
+package suite
+
+import (
+	"runtime/debug"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/internal/goroutines"
+)
+
+// testTimeout returns the timeout of the named test, or 0 if the suite
+// does not declare one.
+func testTimeout(suite TestingSuite, testName string) time.Duration {
+	if withTimeout, ok := suite.(WithTimeout); ok {
+		return withTimeout.Timeout(testName)
+	}
+	return 0
+}
+
+// callWithTimeout calls f in a new goroutine and waits at most timeout for
+// it to return. If f panics, the recovered value and the stack of the panic
+// are returned. When the timeout expires, the stack of the goroutine running
+// f is captured, cancel is called so f can stop, and the test fails with
+// that stack. The goroutine is left running.
+func callWithTimeout(t *testing.T, timeout time.Duration, cancel func(), f func()) (recovered interface{}, stack []byte) {
+	t.Helper()
+
+	type result struct {
+		recovered interface{}
+		stack     []byte
+	}
+
+	ids := make(chan int64, 1)
+	done := make(chan result, 1)
+	go func() {
+		ids <- goroutines.CurrentID()
+		defer func() {
+			// recover returns nil when f calls runtime.Goexit,
+			// for example through t.FailNow.
+			r := recover()
+			res := result{recovered: r}
+			if r != nil {
+				res.stack = debug.Stack()
+			}
+			done <- res
+		}()
+		f()
+	}()
+	id := <-ids
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case res := <-done:
+		return res.recovered, res.stack
+	case <-timer.C:
+		// Capture the stack before cancelling: f may return as soon as
+		// its context is done, taking its stack with it.
+		stack := goroutines.Stack(id)
+		cancel()
+		t.Fatalf("test timed out after %s\n%s", timeout, stack)
+	}
+	return nil, nil
+}